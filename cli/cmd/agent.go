@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/letta/letta-switchboard-cli/internal/config"
+)
+
+// resolveAgentID returns the --agent-id value, falling back to
+// default_agent_id from the config, and checks it with validateAgentID
+func resolveAgentID(cfg *config.Config, agentID string) (string, error) {
+	if agentID == "" {
+		agentID = cfg.DefaultAgentID
+	}
+	if agentID == "" {
+		return "", fmt.Errorf("agent-id is required: pass --agent-id or set a default with 'letta-switchboard config set-agent <agent-id>'")
+	}
+	if err := validateAgentID(cfg, agentID); err != nil {
+		return "", err
+	}
+	return agentID, nil
+}
+
+// validateAgentID checks an agent ID against the configured agent_id_pattern
+// before it is sent, so typos fail fast instead of as a server error
+func validateAgentID(cfg *config.Config, agentID string) error {
+	if cfg.AgentIDPattern == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(cfg.AgentIDPattern)
+	if err != nil {
+		return fmt.Errorf("invalid agent_id_pattern in config: %w", err)
+	}
+
+	if !re.MatchString(agentID) {
+		return fmt.Errorf("agent ID %q doesn't look like a Letta agent ID (expected to match %s)\n"+
+			"If the ID is correct, relax agent_id_pattern in the config file or set it to \"\" to disable this check", agentID, cfg.AgentIDPattern)
+	}
+
+	return nil
+}
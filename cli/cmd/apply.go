@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/letta/letta-switchboard-cli/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+// applyEntry is one schedule in a batch file. Entries with cron are
+// recurring, entries with execute_at are one-time.
+type applyEntry struct {
+	AgentID     string   `yaml:"agent_id"`
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Message     string   `yaml:"message"`
+	Role        string   `yaml:"role"`
+	Cron        string   `yaml:"cron"`
+	ExecuteAt   string   `yaml:"execute_at"`
+	Tags        []string `yaml:"tags"`
+}
+
+// plannedSchedule is a validated batch entry ready to be created
+type plannedSchedule struct {
+	Index int
+	// Cron is the recurring entry's expression as standard cron, before
+	// serverCron renumbers its weekdays into Recurring.CronString
+	Cron      string
+	Recurring *client.RecurringScheduleCreate
+	OneTime   *client.OneTimeScheduleCreate
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create schedules from a batch file",
+	Long: `Create recurring and one-time schedules from a YAML or JSON file holding a
+list of entries. Every entry is validated before anything is created.
+
+  - agent_id: agent-xxx
+    message: "Daily summary please"
+    cron: "daily at 9am"
+    role: system
+    tags: [reports]
+  - agent_id: agent-xxx
+    message: "Follow up"
+    execute_at: "tomorrow at 10am"
+
+Recurring entries that exactly match a live schedule, by agent and name or,
+without a name, by agent, cron and message, are skipped as unchanged, like
+recurring ensure, so applying the same file again creates nothing new. Live
+schedules are never updated; see recurring diff.
+
+role defaults to recurring_default_role or onetime_default_role from the
+config ("user" unless changed). Recurring entries that fire more often than
+min_cron_interval are rejected unless --force is given. Use --file - to read
+from stdin.
+
+Progress is saved to <file>.apply-state.json (or --state-file) as entries are
+created. If the run is interrupted or some entries fail, fix the problem and
+run it again with --resume to skip the entries already created; the state
+file is removed once every entry has been applied.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		allowPast, _ := cmd.Flags().GetBool("allow-past")
+		dialectName, _ := cmd.Flags().GetString("cron-dialect")
+		resume, _ := cmd.Flags().GetBool("resume")
+		stateFile, _ := cmd.Flags().GetString("state-file")
+		if file == "" {
+			return fmt.Errorf("--file is required")
+		}
+		statePath := applyStatePath(file, stateFile)
+		if resume && statePath == "" {
+			return fmt.Errorf("--resume needs --state-file when the batch is read from stdin")
+		}
+		strictMessage, _ := cmd.Flags().GetBool("strict-message")
+		force, _ := cmd.Flags().GetBool("force")
+		dialect, err := parser.ParseCronDialect(dialectName)
+		if err != nil {
+			return err
+		}
+		opts := applyOptions{AllowPast: allowPast, Dialect: dialect, StrictMessage: strictMessage, Force: force}
+
+		data, err := readApplyFile(file)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		entries, err := parseApplyFile(cfg, data)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Println("No schedules to apply")
+			return nil
+		}
+
+		plan, err := planApply(cfg, entries, opts)
+		if err != nil {
+			return err
+		}
+
+		state, err := startApplyState(statePath, file, resume)
+		if err != nil {
+			return err
+		}
+		keys := applyEntryKeys(entries)
+
+		apiClient := newAPIClient(cmd, cfg)
+		var live map[string]string
+		if resume {
+			if live, err = liveScheduleNames(apiClient); err != nil {
+				return err
+			}
+		}
+		unchanged, err := unchangedEntries(apiClient, plan)
+		if err != nil {
+			return err
+		}
+
+		stop := failFast(cmd)
+		created, skipped, kept := 0, 0, 0
+		var failures []string
+		for _, p := range plan {
+			if cmd.Context().Err() != nil {
+				break
+			}
+
+			key := keys[p.Index-1]
+			id, done := state.Created[key]
+			if !done {
+				id, done = live[key]
+			}
+			if done {
+				skipped++
+				fmt.Printf("= entry %d: already created %s\n", p.Index, id)
+				continue
+			}
+			if id, ok := unchanged[p.Index]; ok {
+				kept++
+				fmt.Printf("= entry %d: unchanged %s\n", p.Index, id)
+				continue
+			}
+
+			id, err := applyPlanned(apiClient, p)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("entry %d: failed to create schedule: %v", p.Index, err))
+				if stop {
+					break
+				}
+				continue
+			}
+			created++
+			state.Created[key] = id
+			if statePath != "" {
+				if err := state.save(statePath); err != nil {
+					return err
+				}
+			}
+		}
+		invalidateCache(cfg, recurringCacheKey, onetimeCacheKey)
+
+		total := len(plan) - skipped - kept
+		if cmd.Context().Err() != nil {
+			if statePath == "" {
+				return fmt.Errorf("interrupted after creating %d of %d schedules", created, total)
+			}
+			return fmt.Errorf("interrupted after creating %d of %d schedules; progress is saved in %s, run again with --resume to continue", created, total, statePath)
+		}
+		if len(failures) > 0 {
+			if statePath != "" {
+				fmt.Fprintf(os.Stderr, "Progress is saved in %s; run again with --resume to skip the entries already created\n", statePath)
+			}
+			return bulkFailure("created", created, total, stop, errors.New(strings.Join(failures, "\n  ")))
+		}
+
+		if statePath != "" {
+			if err := os.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				fmt.Fprintf(os.Stderr, "Warning: failed to remove apply state: %v\n", err)
+			}
+		}
+		summary := fmt.Sprintf("Applied %d schedules", created)
+		if skipped > 0 {
+			summary += fmt.Sprintf(", %d already created", skipped)
+		}
+		if kept > 0 {
+			summary += fmt.Sprintf(", %d unchanged", kept)
+		}
+		color.Green("\n✓ %s", summary)
+		return nil
+	},
+}
+
+// unchangedEntries maps the index of each recurring entry that exactly
+// matches a live schedule to that schedule's ID, matching entries the same
+// way recurring diff does
+func unchangedEntries(apiClient *client.Client, plan []plannedSchedule) (map[int]string, error) {
+	unchanged := map[int]string{}
+	var live []client.RecurringSchedule
+	listed := false
+	matched := make(map[string]bool)
+	for _, p := range plan {
+		if p.Recurring == nil {
+			continue
+		}
+		if !listed {
+			var err error
+			if live, err = apiClient.ListRecurringSchedules(); err != nil {
+				return nil, fmt.Errorf("failed to list schedules: %w", err)
+			}
+			listed = true
+		}
+		existing := matchLiveSchedule(live, p.Recurring, matched)
+		if existing == nil {
+			continue
+		}
+		matched[existing.ID] = true
+		if len(recurringFieldDiffs(existing, p.Recurring)) == 0 {
+			unchanged[p.Index] = existing.ID
+		}
+	}
+	return unchanged, nil
+}
+
+// startApplyState loads the saved progress for --resume, or starts afresh.
+// Without --resume an existing state file means an earlier run didn't
+// finish, and starting over would create its entries twice.
+func startApplyState(path, file string, resume bool) (*applyState, error) {
+	fresh := &applyState{File: file, Created: map[string]string{}}
+	if path == "" {
+		return fresh, nil
+	}
+
+	state, err := loadApplyState(path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case state != nil && !resume:
+		return nil, fmt.Errorf("%s is left from an earlier apply that didn't finish: pass --resume to continue it, or delete the file to start over", path)
+	case state == nil && resume:
+		return nil, fmt.Errorf("nothing to resume: %s not found", path)
+	case state == nil:
+		return fresh, nil
+	}
+	return state, nil
+}
+
+// applyPlanned creates one validated batch entry, reports it and returns
+// the new schedule's ID
+func applyPlanned(apiClient *client.Client, p plannedSchedule) (string, error) {
+	if p.Recurring != nil {
+		schedule, err := apiClient.CreateRecurringSchedule(*p.Recurring)
+		if err != nil {
+			return "", err
+		}
+		fmt.Printf("✓ entry %d: recurring %s (%s)\n", p.Index, schedule.ID, schedule.CronString)
+		return schedule.ID, nil
+	}
+
+	schedule, err := apiClient.CreateOneTimeSchedule(*p.OneTime)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("✓ entry %d: one-time %s (%s)\n", p.Index, schedule.ID, schedule.ExecuteAt)
+	return schedule.ID, nil
+}
+
+// readApplyFile reads the batch file at path, or stdin for "-"
+func readApplyFile(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %w", err)
+	}
+	return data, nil
+}
+
+// applyOptions are the command-line settings that affect how entries are
+// validated
+type applyOptions struct {
+	AllowPast     bool
+	Dialect       parser.CronDialect
+	StrictMessage bool
+	// Force skips the min_cron_interval check
+	Force bool
+}
+
+// planApply validates every entry, reporting all problems at once with
+// 1-based entry numbers, and resolves schedules and times for creation
+func planApply(cfg *config.Config, entries []applyEntry, opts applyOptions) ([]plannedSchedule, error) {
+	var plan []plannedSchedule
+	var problems []string
+	named := map[string]int{}
+
+	for i, e := range entries {
+		index := i + 1
+		if name := strings.TrimSpace(e.Name); name != "" {
+			key := namedEntryKey(applyEntryType(e), e.AgentID, name)
+			if first, ok := named[key]; ok {
+				problems = append(problems, fmt.Sprintf("entry %d: name %q is already used by entry %d for the same agent", index, name, first))
+				continue
+			}
+			named[key] = index
+		}
+		p, err := planEntry(cfg, e, opts)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("entry %d: %v", index, err))
+			continue
+		}
+		p.Index = index
+		plan = append(plan, p)
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New("invalid batch file:\n  " + strings.Join(problems, "\n  "))
+	}
+	return plan, nil
+}
+
+func planEntry(cfg *config.Config, e applyEntry, opts applyOptions) (plannedSchedule, error) {
+	if e.AgentID == "" || e.Message == "" {
+		return plannedSchedule{}, fmt.Errorf("agent_id and message are required")
+	}
+	message, err := cleanMessage(e.Message, opts.StrictMessage)
+	if err != nil {
+		return plannedSchedule{}, err
+	}
+	if (e.Cron == "") == (e.ExecuteAt == "") {
+		return plannedSchedule{}, fmt.Errorf("set exactly one of cron or execute_at")
+	}
+	if err := validateAgentID(cfg, e.AgentID); err != nil {
+		return plannedSchedule{}, err
+	}
+
+	role := e.Role
+	if role == "" {
+		role = cfg.OneTimeDefaultRole
+		if e.Cron != "" {
+			role = cfg.RecurringDefaultRole
+		}
+	}
+	role, err = resolveRole(cfg, role)
+	if err != nil {
+		return plannedSchedule{}, err
+	}
+
+	tags, err := normalizeTags(e.Tags)
+	if err != nil {
+		return plannedSchedule{}, err
+	}
+
+	if e.Cron != "" {
+		cronString, err := parser.ParseCronAs(e.Cron, opts.Dialect)
+		if err != nil {
+			return plannedSchedule{}, fmt.Errorf("failed to parse cron: %w", err)
+		}
+		if !opts.Force {
+			if err := checkCronFrequency(cfg, cronString); err != nil {
+				return plannedSchedule{}, err
+			}
+		}
+		serverString, err := serverCron(cfg, cronString)
+		if err != nil {
+			return plannedSchedule{}, err
+		}
+		return plannedSchedule{Cron: cronString, Recurring: &client.RecurringScheduleCreate{
+			AgentID:     e.AgentID,
+			Name:        strings.TrimSpace(e.Name),
+			Description: strings.TrimSpace(e.Description),
+			Message:     message,
+			Role:        role,
+			CronString:  serverString,
+			Tags:        tags,
+		}}, nil
+	}
+
+	executeAt, err := parseUserTime(cfg, e.ExecuteAt)
+	if err != nil {
+		return plannedSchedule{}, fmt.Errorf("failed to parse execute_at: %w", err)
+	}
+	if err := checkNotPast(executeAt, opts.AllowPast); err != nil {
+		return plannedSchedule{}, err
+	}
+	return plannedSchedule{OneTime: &client.OneTimeScheduleCreate{
+		AgentID:     e.AgentID,
+		Name:        strings.TrimSpace(e.Name),
+		Description: strings.TrimSpace(e.Description),
+		Message:     message,
+		Role:        role,
+		ExecuteAt:   executeAt,
+		Tags:        tags,
+	}}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	applyCmd.Flags().StringP("file", "f", "", "Batch file of schedules (YAML or JSON), or - for stdin")
+	applyCmd.Flags().Bool("allow-past", false, "Allow execute_at times in the past")
+	applyCmd.Flags().Bool("force", false, "Create recurring entries even if they fire more often than min_cron_interval")
+	addStrictMessageFlag(applyCmd)
+	addFailureModeFlags(applyCmd)
+	applyCmd.Flags().Bool("resume", false, "Continue an apply that was interrupted or failed part way, skipping entries already created")
+	applyCmd.Flags().String("state-file", "", "Where to save progress (default <file>"+applyStateSuffix+"; none for stdin)")
+	addCronDialectFlag(applyCmd)
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// applyFieldKinds are the keys a batch entry may have and the YAML shape of
+// each value: tags is a list, everything else a single value
+var applyFieldKinds = map[string]yaml.Kind{
+	"agent_id":    yaml.ScalarNode,
+	"name":        yaml.ScalarNode,
+	"description": yaml.ScalarNode,
+	"message":     yaml.ScalarNode,
+	"role":        yaml.ScalarNode,
+	"cron":        yaml.ScalarNode,
+	"execute_at":  yaml.ScalarNode,
+	"tags":        yaml.SequenceNode,
+}
+
+// parseApplyFile checks the structure of a batch file and decodes its
+// entries. Structural problems, such as unknown or missing fields, an
+// invalid role, or both cron and execute_at, are all reported together with
+// their line and entry numbers before any entry is interpreted.
+func parseApplyFile(cfg *config.Config, data []byte) ([]applyEntry, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse batch file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("invalid batch file: line %d: expected a list of entries", root.Line)
+	}
+
+	var problems []string
+	for i, item := range root.Content {
+		for _, problem := range checkApplyEntry(cfg, item) {
+			problems = append(problems, fmt.Sprintf("line %d, entry %d: %s", problem.line, i+1, problem.msg))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.New("invalid batch file:\n  " + strings.Join(problems, "\n  "))
+	}
+
+	var entries []applyEntry
+	if err := root.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to parse batch file: %w", err)
+	}
+	return entries, nil
+}
+
+// schemaProblem is a structural error at a line of the batch file
+type schemaProblem struct {
+	line int
+	msg  string
+}
+
+// checkApplyEntry returns the structural problems of one batch entry
+func checkApplyEntry(cfg *config.Config, entry *yaml.Node) []schemaProblem {
+	if entry.Kind != yaml.MappingNode {
+		return []schemaProblem{{entry.Line, "expected a mapping of fields"}}
+	}
+
+	var problems []schemaProblem
+	values := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(entry.Content); i += 2 {
+		key, value := entry.Content[i], entry.Content[i+1]
+		kind, ok := applyFieldKinds[key.Value]
+		if !ok {
+			problems = append(problems, schemaProblem{key.Line, fmt.Sprintf("unknown field %q (expected one of: %s)", key.Value, strings.Join(applyFieldNames(), ", "))})
+			continue
+		}
+		if value.Kind != kind {
+			problems = append(problems, schemaProblem{value.Line, fmt.Sprintf("%s must be %s", key.Value, kindName(kind))})
+			continue
+		}
+		if kind == yaml.SequenceNode {
+			for _, item := range value.Content {
+				if item.Kind != yaml.ScalarNode {
+					problems = append(problems, schemaProblem{item.Line, fmt.Sprintf("%s entries must be single values", key.Value)})
+				}
+			}
+		}
+		values[key.Value] = value
+	}
+
+	for _, field := range []string{"agent_id", "message"} {
+		if value, ok := values[field]; !ok || strings.TrimSpace(value.Value) == "" {
+			problems = append(problems, schemaProblem{entry.Line, field + " is required"})
+		}
+	}
+
+	cron, hasCron := values["cron"]
+	executeAt, hasExecuteAt := values["execute_at"]
+	switch {
+	case hasCron && hasExecuteAt:
+		problems = append(problems, schemaProblem{executeAt.Line, "set either cron or execute_at, not both"})
+	case !hasCron && !hasExecuteAt:
+		problems = append(problems, schemaProblem{entry.Line, "one of cron or execute_at is required"})
+	case hasCron && strings.TrimSpace(cron.Value) == "":
+		problems = append(problems, schemaProblem{cron.Line, "cron is empty"})
+	case hasExecuteAt && strings.TrimSpace(executeAt.Value) == "":
+		problems = append(problems, schemaProblem{executeAt.Line, "execute_at is empty"})
+	}
+
+	if role, ok := values["role"]; ok && role.Kind == yaml.ScalarNode && role.Value != "" {
+		if _, err := resolveRole(cfg, role.Value); err != nil {
+			problems = append(problems, schemaProblem{role.Line, err.Error()})
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].line < problems[j].line })
+	return problems
+}
+
+// applyFieldNames returns the batch entry keys in sorted order
+func applyFieldNames() []string {
+	names := make([]string, 0, len(applyFieldKinds))
+	for name := range applyFieldKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// kindName describes a YAML node kind in error messages
+func kindName(kind yaml.Kind) string {
+	if kind == yaml.SequenceNode {
+		return "a list"
+	}
+	return "a single value"
+}
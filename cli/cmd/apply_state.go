@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+)
+
+// applyStateSuffix is added to the batch file's path to name its state file
+const applyStateSuffix = ".apply-state.json"
+
+// applyState records which batch entries an apply has created, so a run
+// that was interrupted or failed part way can be resumed without creating
+// them again
+type applyState struct {
+	File string `json:"file"`
+	// Created maps each created entry's key to the new schedule's ID
+	Created map[string]string `json:"created"`
+}
+
+// applyStatePath returns where apply keeps its progress: --state-file, or
+// next to the batch file. Batches read from stdin have no state unless
+// --state-file is given.
+func applyStatePath(file, stateFile string) string {
+	if stateFile != "" || file == "-" {
+		return stateFile
+	}
+	return file + applyStateSuffix
+}
+
+// loadApplyState reads the state file at path, returning nil when it
+// doesn't exist
+func loadApplyState(path string) (*applyState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read apply state: %w", err)
+	}
+
+	var state applyState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse apply state %s: %w", path, err)
+	}
+	if state.Created == nil {
+		state.Created = map[string]string{}
+	}
+	return &state, nil
+}
+
+// save writes the state to path after every created entry, so it is
+// current whenever the run stops
+func (s *applyState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode apply state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to save apply state: %w", err)
+	}
+	return nil
+}
+
+// applyEntryKeys identifies each batch entry across runs. Named entries are
+// keyed by type, agent and name, so editing their other fields doesn't
+// create them twice; unnamed entries by a hash of their fields and how many identical
+// entries came before, so intentional duplicates each get created.
+func applyEntryKeys(entries []applyEntry) []string {
+	keys := make([]string, len(entries))
+	seen := map[string]int{}
+	for i, e := range entries {
+		if name := strings.TrimSpace(e.Name); name != "" {
+			keys[i] = namedEntryKey(applyEntryType(e), e.AgentID, name)
+			continue
+		}
+		data, _ := json.Marshal(e)
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:8])
+		keys[i] = fmt.Sprintf("sha256:%s#%d", hash, seen[hash])
+		seen[hash]++
+	}
+	return keys
+}
+
+// namedEntryKey is the key of a named schedule of type typ for agentID
+func namedEntryKey(typ, agentID, name string) string {
+	return fmt.Sprintf("name:%s:%s:%s", typ, agentID, name)
+}
+
+// applyEntryType names the kind of schedule an entry creates
+func applyEntryType(e applyEntry) string {
+	if e.Cron != "" {
+		return "recurring"
+	}
+	return "one-time"
+}
+
+// liveScheduleNames returns the keys of existing named schedules, by type,
+// agent and name, for resuming: an entry whose request was cut off may have been created
+// without being recorded
+func liveScheduleNames(apiClient *client.Client) (map[string]string, error) {
+	names := map[string]string{}
+	recurring, err := apiClient.ListRecurringSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring schedules: %w", err)
+	}
+	for _, s := range recurring {
+		if s.Name != "" {
+			names[namedEntryKey("recurring", s.AgentID, s.Name)] = s.ID
+		}
+	}
+	onetime, err := apiClient.ListOneTimeSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list one-time schedules: %w", err)
+	}
+	for _, s := range onetime {
+		if s.Name != "" {
+			names[namedEntryKey("one-time", s.AgentID, s.Name)] = s.ID
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/letta/letta-switchboard-cli/internal/config"
+)
+
+func TestPlanApplyChecksCronFrequency(t *testing.T) {
+	cfg := &config.Config{MinCronInterval: 5 * time.Minute, RecurringDefaultRole: "user"}
+	entries := []applyEntry{
+		{AgentID: "agent-1", Message: "ok", Cron: "every 15 minutes"},
+		{AgentID: "agent-1", Message: "too often", Cron: "* * * * *"},
+	}
+
+	_, err := planApply(cfg, entries, applyOptions{})
+	if err == nil || !strings.Contains(err.Error(), "entry 2:") || !strings.Contains(err.Error(), "min_cron_interval") {
+		t.Fatalf("planApply error = %v, want entry 2 rejected for min_cron_interval", err)
+	}
+	if strings.Contains(err.Error(), "entry 1:") {
+		t.Errorf("planApply error = %v, want entry 1 accepted", err)
+	}
+
+	plan, err := planApply(cfg, entries, applyOptions{Force: true})
+	if err != nil {
+		t.Fatalf("planApply with Force returned error: %v", err)
+	}
+	if len(plan) != 2 || plan[1].Recurring.CronString != "* * * * *" {
+		t.Errorf("planApply with Force = %+v, want both entries planned", plan)
+	}
+
+	cfg.MinCronInterval = 0
+	if _, err := planApply(cfg, entries, applyOptions{}); err != nil {
+		t.Errorf("planApply with min_cron_interval 0 returned error: %v", err)
+	}
+}
+
+func TestPlanApplyKeepsStandardCron(t *testing.T) {
+	cfg := &config.Config{CronWeekdays: "sunday-1", RecurringDefaultRole: "user"}
+	entries := []applyEntry{{AgentID: "agent-1", Message: "weekend", Cron: "every fri to sun at 8am"}}
+
+	plan, err := planApply(cfg, entries, applyOptions{})
+	if err != nil {
+		t.Fatalf("planApply returned error: %v", err)
+	}
+	if plan[0].Cron != "0 8 * * 0,5-6" {
+		t.Errorf("Cron = %q, want the standard %q", plan[0].Cron, "0 8 * * 0,5-6")
+	}
+	if plan[0].Recurring.CronString != "0 8 * * 1,6-7" {
+		t.Errorf("CronString = %q, want the renumbered %q", plan[0].Recurring.CronString, "0 8 * * 1,6-7")
+	}
+}
+
+func TestApplyEntryKeysIncludeAgent(t *testing.T) {
+	entries := []applyEntry{
+		{AgentID: "agent-a", Name: "standup", Message: "hi", Cron: "daily"},
+		{AgentID: "agent-b", Name: "standup", Message: "hi", Cron: "daily"},
+		{AgentID: "agent-a", Name: "standup", Message: "hi", ExecuteAt: "tomorrow"},
+	}
+	keys := applyEntryKeys(entries)
+	if keys[0] == keys[1] || keys[0] == keys[2] {
+		t.Errorf("applyEntryKeys = %q, want a distinct key per agent and type", keys)
+	}
+	if keys[1] != "name:recurring:agent-b:standup" {
+		t.Errorf("key = %q, want %q", keys[1], "name:recurring:agent-b:standup")
+	}
+}
+
+func TestPlanApplyRejectsDuplicateNames(t *testing.T) {
+	cfg := &config.Config{RecurringDefaultRole: "user"}
+	entries := []applyEntry{
+		{AgentID: "agent-a", Name: "standup", Message: "hi", Cron: "daily"},
+		{AgentID: "agent-b", Name: "standup", Message: "hi", Cron: "daily"},
+		{AgentID: "agent-a", Name: " standup ", Message: "again", Cron: "weekly"},
+	}
+
+	_, err := planApply(cfg, entries, applyOptions{})
+	if err == nil || !strings.Contains(err.Error(), `entry 3: name "standup" is already used by entry 1`) {
+		t.Fatalf("planApply error = %v, want entry 3 rejected as a duplicate of entry 1", err)
+	}
+	if strings.Contains(err.Error(), "entry 2:") {
+		t.Errorf("planApply error = %v, want the same name for another agent accepted", err)
+	}
+}
+
+func TestUnchangedEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"id":"rs-1","agent_id":"agent-a","name":"standup","message":"hi","role":"user","cron":"0 9 * * *"},
+			{"id":"rs-2","agent_id":"agent-b","message":"yo","role":"user","cron":"*/15 * * * *"}
+		]`)
+	}))
+	defer server.Close()
+	apiClient := client.NewClient(server.URL, "test-key", client.WithHTTPClient(server.Client()))
+
+	recurring := func(index int, agentID, name, message, cron string) plannedSchedule {
+		return plannedSchedule{Index: index, Recurring: &client.RecurringScheduleCreate{
+			AgentID: agentID, Name: name, Message: message, Role: "user", CronString: cron,
+		}}
+	}
+	plan := []plannedSchedule{
+		recurring(1, "agent-a", "standup", "hi", "0 9 * * *"),
+		recurring(2, "agent-b", "", "yo", "*/15 * * * *"),
+		// a second identical entry can't match the schedule entry 2 took
+		recurring(3, "agent-b", "", "yo", "*/15 * * * *"),
+		// the same name for another agent is a different schedule
+		recurring(4, "agent-c", "standup", "hi", "0 9 * * *"),
+	}
+	plan[0].Recurring.Message = "hello"
+
+	unchanged, err := unchangedEntries(apiClient, plan)
+	if err != nil {
+		t.Fatalf("unchangedEntries returned error: %v", err)
+	}
+	if len(unchanged) != 1 || unchanged[2] != "rs-2" {
+		t.Errorf("unchangedEntries = %v, want only entry 2 as rs-2", unchanged)
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultBulkLimit is how many schedules a bulk operation may touch without confirmation
+	defaultBulkLimit = 10
+	// bulkSampleSize is how many IDs are shown before asking for confirmation
+	bulkSampleSize = 5
+)
+
+// addBulkFlags registers the confirmation flags for bulk operations
+func addBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("limit", defaultBulkLimit, "Ask for confirmation when more than this many schedules are affected")
+	cmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	addFailureModeFlags(cmd)
+}
+
+// addFailureModeFlags registers --fail-fast and --continue-on-error, which
+// choose whether a batch stops at its first failure. Continuing is the
+// default; setting both is rejected by checkFlagConflicts.
+func addFailureModeFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("fail-fast", false, "Stop at the first failure")
+	cmd.Flags().Bool("continue-on-error", false, "Keep going after a failure and report all failures at the end (default)")
+}
+
+// failFast reports whether cmd should stop at its first failure
+func failFast(cmd *cobra.Command) bool {
+	stop, _ := cmd.Flags().GetBool("fail-fast")
+	return stop
+}
+
+// confirmBulk asks before acting on more than --limit schedules, showing the
+// count and a sample of IDs. It fails rather than prompting when stdin is not
+// a terminal, so scripts must pass --yes explicitly.
+func confirmBulk(cmd *cobra.Command, action string, ids []string) (bool, error) {
+	limit, _ := cmd.Flags().GetInt("limit")
+	yes, _ := cmd.Flags().GetBool("yes")
+	if yes || len(ids) <= limit {
+		return true, nil
+	}
+
+	fmt.Printf("This will %s %d schedules:\n", action, len(ids))
+	for i, id := range ids {
+		if i == bulkSampleSize {
+			fmt.Printf("  ... and %d more\n", len(ids)-bulkSampleSize)
+			break
+		}
+		fmt.Printf("  %s\n", id)
+	}
+
+	if !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		return false, fmt.Errorf("refusing to %s %d schedules without confirmation (more than --limit %d); pass --yes to proceed", action, len(ids), limit)
+	}
+
+	fmt.Print("Continue? [y/N]: ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, nil
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// runBulk applies fn to each ID and returns how many succeeded. With stop
+// set it gives up at the first failure; otherwise it tries every ID and
+// returns all failures together.
+func runBulk(ids []string, stop bool, fn func(id string) error) (int, error) {
+	succeeded := 0
+	var failures []string
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			if stop {
+				return succeeded, fmt.Errorf("%s: %w", id, err)
+			}
+			failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		succeeded++
+	}
+	if len(failures) > 0 {
+		return succeeded, errors.New(strings.Join(failures, "\n  "))
+	}
+	return succeeded, nil
+}
+
+// runBulkConcurrent is runBulk with up to workers calls to fn in flight at
+// once. With stop set no new calls start after the first failure. Failures
+// are reported in the order of ids.
+func runBulkConcurrent(ids []string, workers int, stop bool, fn func(id string) error) (int, error) {
+	if workers <= 1 {
+		return runBulk(ids, stop, fn)
+	}
+
+	errs := make([]error, len(ids))
+	done := make([]bool, len(ids))
+	var mu sync.Mutex
+	failed := false
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	for i, id := range ids {
+		sem <- struct{}{}
+		mu.Lock()
+		skip := stop && failed
+		mu.Unlock()
+		if skip {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := fn(id)
+			mu.Lock()
+			errs[i], done[i] = err, true
+			failed = failed || err != nil
+			mu.Unlock()
+		}(i, id)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	var failures []string
+	for i, id := range ids {
+		switch {
+		case !done[i]:
+		case errs[i] != nil:
+			failures = append(failures, fmt.Sprintf("%s: %v", id, errs[i]))
+		default:
+			succeeded++
+		}
+	}
+	if len(failures) > 0 {
+		return succeeded, errors.New(strings.Join(failures, "\n  "))
+	}
+	return succeeded, nil
+}
+
+// bulkFailure summarizes a batch that didn't fully succeed, e.g. "deleted 3
+// of 5 schedules; 2 failed" followed by the failures
+func bulkFailure(done string, succeeded, total int, stop bool, err error) error {
+	if stop {
+		return fmt.Errorf("%s %d of %d schedules, then stopped at the first failure: %w", done, succeeded, total, err)
+	}
+	return fmt.Errorf("%s %d of %d schedules; %d failed:\n  %w", done, succeeded, total, total-succeeded, err)
+}
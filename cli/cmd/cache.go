@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/letta/letta-switchboard-cli/internal/cache"
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const (
+	recurringCacheKey = "recurring"
+	onetimeCacheKey   = "onetime"
+)
+
+// openCache returns the list cache for the configured server and API key.
+// It is always usable for invalidation, regardless of whether caching is
+// enabled for this invocation.
+func openCache(cfg *config.Config) (*cache.Cache, error) {
+	return cache.New(filepath.Join(cfg.Dir(), cache.DirName, cacheScope(cfg)), cfg.CacheTTL), nil
+}
+
+// cacheScope names the cache subdirectory for the primary base URL and API
+// key, so switching servers or keys never shows another one's schedules. The
+// pair is hashed to keep the key out of the path.
+func cacheScope(cfg *config.Config) string {
+	baseURL := strings.TrimRight(cfg.Endpoints()[0], "/")
+	sum := sha256.Sum256([]byte(baseURL + "\x00" + cfg.APIKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cacheEnabled reports whether list results should be cached and reused.
+// --no-cache or --cache (never both, see flagConflicts) wins over the
+// config file. Acting for another user with --on-behalf-of never uses the
+// cache, which holds what the API key itself sees.
+func cacheEnabled(cmd *cobra.Command, cfg *config.Config) bool {
+	if noCache, _ := cmd.Flags().GetBool("no-cache"); noCache {
+		return false
+	}
+	if onBehalfOf, _ := cmd.Flags().GetString("on-behalf-of"); onBehalfOf != "" {
+		return false
+	}
+	if useCache, _ := cmd.Flags().GetBool("cache"); useCache {
+		return true
+	}
+	return cfg.Cache
+}
+
+// storeCache saves a list response when caching is enabled
+func storeCache(cmd *cobra.Command, cfg *config.Config, key string, v interface{}) {
+	if !cacheEnabled(cmd, cfg) {
+		return
+	}
+	c, err := openCache(cfg)
+	if err == nil {
+		err = c.Set(key, v)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// invalidateCache drops cached list responses after a write operation
+func invalidateCache(cfg *config.Config, keys ...string) {
+	c, err := openCache(cfg)
+	if err == nil {
+		err = c.Invalidate(keys...)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// cachedRecurringSchedule looks up a recurring schedule in the cached list
+func cachedRecurringSchedule(cmd *cobra.Command, cfg *config.Config, scheduleID string) *client.RecurringSchedule {
+	if !cacheEnabled(cmd, cfg) {
+		return nil
+	}
+	c, err := openCache(cfg)
+	if err != nil {
+		return nil
+	}
+
+	var schedules []client.RecurringSchedule
+	if ok, _ := c.Get(recurringCacheKey, &schedules); !ok {
+		return nil
+	}
+	for i := range schedules {
+		if schedules[i].ID == scheduleID {
+			return &schedules[i]
+		}
+	}
+	return nil
+}
+
+// cachedOneTimeSchedule looks up a one-time schedule in the cached list
+func cachedOneTimeSchedule(cmd *cobra.Command, cfg *config.Config, scheduleID string) *client.OneTimeSchedule {
+	if !cacheEnabled(cmd, cfg) {
+		return nil
+	}
+	c, err := openCache(cfg)
+	if err != nil {
+		return nil
+	}
+
+	var schedules []client.OneTimeSchedule
+	if ok, _ := c.Get(onetimeCacheKey, &schedules); !ok {
+		return nil
+	}
+	for i := range schedules {
+		if schedules[i].ID == scheduleID {
+			return &schedules[i]
+		}
+	}
+	return nil
+}
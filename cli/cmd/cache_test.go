@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/letta/letta-switchboard-cli/internal/config"
+)
+
+func TestCacheScope(t *testing.T) {
+	scope := func(baseURL, apiKey string) string {
+		return cacheScope(&config.Config{BaseURL: baseURL, APIKey: apiKey})
+	}
+
+	base := scope("https://a.example", "key-1")
+	if got := scope("https://a.example/", "key-1"); got != base {
+		t.Errorf("trailing slash changed the scope: %s != %s", got, base)
+	}
+	if got := scope("https://b.example", "key-1"); got == base {
+		t.Error("another server shares the cache scope")
+	}
+	if got := scope("https://a.example", "key-2"); got == base {
+		t.Error("another API key shares the cache scope")
+	}
+	if strings.Contains(base, "key-1") {
+		t.Errorf("scope %q contains the API key", base)
+	}
+
+	failover := cacheScope(&config.Config{BaseURLs: []string{"https://a.example", "https://backup.example"}, APIKey: "key-1"})
+	if failover != base {
+		t.Errorf("failover URLs changed the scope: %s != %s", failover, base)
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+)
+
+const (
+	// verboseClockSkew is the smallest clock difference --verbose reports;
+	// the Date header only has second precision, so less is noise
+	verboseClockSkew = 2 * time.Second
+	// warnClockSkew is the difference always warned about, since it visibly
+	// shifts relative times like "in 5 minutes"
+	warnClockSkew = time.Minute
+)
+
+// clockSkewChecker returns an OnResponse hook comparing each response's Date
+// header with the local clock. Relative times are resolved locally, so a
+// wrong local clock makes them fire at the wrong moment without any error.
+// It warns at most once per command, about any noticeable skew with verbose
+// and only about large skew otherwise.
+func clockSkewChecker(verbose bool) func(*http.Response) {
+	var once sync.Once
+	return func(resp *http.Response) {
+		skew, ok := client.ClockSkew(resp, time.Now())
+		if !ok {
+			return
+		}
+
+		direction := "ahead of"
+		if skew < 0 {
+			skew, direction = -skew, "behind"
+		}
+		if skew < verboseClockSkew || (skew < warnClockSkew && !verbose) {
+			return
+		}
+		once.Do(func() {
+			fmt.Fprintf(os.Stderr, "Warning: the local clock is %s %s the server's; relative times like \"in 5 minutes\" will be off by that much\n", skew.Round(time.Second), direction)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/letta/letta-switchboard-cli/internal/config"
@@ -14,13 +15,35 @@ var configCmd = &cobra.Command{
 	Long:  "Configure API credentials and settings for the Letta Schedules CLI",
 }
 
+var initConfigCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a config file with the default settings",
+	Long: `Create a config file holding the default settings, in YAML, JSON or TOML.
+The format follows the file's extension, and later changes are saved in the
+same format.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		path, err := configStore.Init(format)
+		if err != nil {
+			return err
+		}
+		color.Green("✓ Created %s", path)
+		return nil
+	},
+}
+
 var setAPIKeyCmd = &cobra.Command{
 	Use:   "set-api-key [api-key]",
 	Short: "Set the Letta API key",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := args[0]
-		if err := config.SetAPIKey(apiKey); err != nil {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.SetAPIKey(apiKey); err != nil {
 			return fmt.Errorf("failed to set API key: %w", err)
 		}
 		color.Green("✓ API key set successfully")
@@ -29,12 +52,16 @@ var setAPIKeyCmd = &cobra.Command{
 }
 
 var setURLCmd = &cobra.Command{
-	Use:   "set-url [url]",
+	Use:   "set-url [url] [fallback-url...]",
 	Short: "Set the API base URL",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Set the API base URL. Additional URLs are used in order when the primary is unreachable.",
+	Args:  cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		url := args[0]
-		if err := config.SetBaseURL(url); err != nil {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.SetBaseURLs(args); err != nil {
 			return fmt.Errorf("failed to set base URL: %w", err)
 		}
 		color.Green("✓ Base URL set successfully")
@@ -42,25 +69,61 @@ var setURLCmd = &cobra.Command{
 	},
 }
 
+var setAgentCmd = &cobra.Command{
+	Use:   "set-agent [agent-id]",
+	Short: "Set the agent ID used when --agent-id is omitted",
+	Long:  "Set default_agent_id, the agent the create commands use when --agent-id is omitted. Pass \"\" to clear it.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentID := strings.TrimSpace(args[0])
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if agentID != "" {
+			if err := validateAgentID(cfg, agentID); err != nil {
+				return err
+			}
+		}
+		if err := cfg.SetDefaultAgentID(agentID); err != nil {
+			return fmt.Errorf("failed to set default agent: %w", err)
+		}
+		if agentID == "" {
+			color.Green("✓ Default agent cleared")
+			return nil
+		}
+		color.Green("✓ Default agent set to %s", agentID)
+		return nil
+	},
+}
+
 var showConfigCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Show current configuration",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
 		fmt.Println("Current configuration:")
-		fmt.Printf("  Base URL: %s\n", cfg.BaseURL)
-		if cfg.APIKey != "" {
+		endpoints := cfg.Endpoints()
+		fmt.Printf("  Base URL: %s\n", endpoints[0])
+		for _, url := range endpoints[1:] {
+			fmt.Printf("  Fallback: %s\n", url)
+		}
+		if len(cfg.APIKey) > 12 {
 			fmt.Printf("  API Key:  %s...%s\n", cfg.APIKey[:8], cfg.APIKey[len(cfg.APIKey)-4:])
+		} else if cfg.APIKey != "" {
+			fmt.Println("  API Key:  (set)")
 		} else {
 			fmt.Println("  API Key:  (not set)")
 		}
+		if cfg.DefaultAgentID != "" {
+			fmt.Printf("  Agent:    %s\n", cfg.DefaultAgentID)
+		}
 
-		configDir, _ := config.GetConfigDir()
-		fmt.Printf("\nConfig file: %s/config.yaml\n", configDir)
+		fmt.Printf("\nConfig file: %s\n", cfg.Path())
 
 		return nil
 	},
@@ -68,7 +131,10 @@ var showConfigCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(initConfigCmd)
+	initConfigCmd.Flags().String("format", "yaml", "Config file format: "+strings.Join(config.ConfigFormats, ", "))
 	configCmd.AddCommand(setAPIKeyCmd)
 	configCmd.AddCommand(setURLCmd)
+	configCmd.AddCommand(setAgentCmd)
 	configCmd.AddCommand(showConfigCmd)
 }
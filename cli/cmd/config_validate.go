@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/letta/letta-switchboard-cli/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+// minAPIKeyLength is the shortest API key config validate accepts as plausible
+const minAPIKeyLength = 8
+
+// configCheck is one named check of config validate
+type configCheck struct {
+	Name  string
+	Check func(cfg *config.Config) (string, error)
+}
+
+// configChecks run in order against the loaded configuration. Each returns
+// a short description of what it found, or an error.
+var configChecks = []configCheck{
+	{"base_url", checkBaseURLs},
+	{"api_key", checkAPIKey},
+	{"display_timezone", func(cfg *config.Config) (string, error) {
+		loc, err := displayLocation(cfg)
+		if err != nil || loc == nil {
+			return "not set, times shown as returned (UTC)", err
+		}
+		return loc.String(), nil
+	}},
+	{"durations", checkDurations},
+	{"retries", func(cfg *config.Config) (string, error) {
+		if cfg.MaxRetries < 0 {
+			return "", fmt.Errorf("max_retries must not be negative, got %d", cfg.MaxRetries)
+		}
+		if cfg.RetryJitter < 0 || cfg.RetryJitter > 1 {
+			return "", fmt.Errorf("retry_jitter must be between 0 and 1, got %g", cfg.RetryJitter)
+		}
+		if err := cfg.ValidateRetryOn(); err != nil {
+			return "", err
+		}
+		retryOn := "default"
+		if len(cfg.RetryOn) > 0 {
+			codes := make([]string, len(cfg.RetryOn))
+			for i, code := range cfg.RetryOn {
+				codes[i] = strconv.Itoa(code)
+			}
+			retryOn = strings.Join(codes, ",")
+		}
+		return fmt.Sprintf("max_retries %d, retry_jitter %g, retry_on %s", cfg.MaxRetries, cfg.RetryJitter, retryOn), nil
+	}},
+	{"max_response_size", func(cfg *config.Config) (string, error) {
+		limit, err := cfg.ResponseSizeLimit()
+		if err != nil || limit == 0 {
+			return "no limit", err
+		}
+		return fmt.Sprintf("%d bytes", limit), nil
+	}},
+	{"output_format", func(cfg *config.Config) (string, error) {
+		if cfg.OutputFormat == "" {
+			return "not set", nil
+		}
+		return cfg.OutputFormat, validateOutputFormatConfig(cfg)
+	}},
+	{"cron_weekdays", func(cfg *config.Config) (string, error) {
+		if cfg.CronWeekdays == "" {
+			return "not set, cron expressions sent as parsed", nil
+		}
+		_, err := parser.ParseWeekdayNumbering(cfg.CronWeekdays)
+		return cfg.CronWeekdays, err
+	}},
+	{"proxy", func(cfg *config.Config) (string, error) {
+		proxyURL, err := cfg.ProxyURL()
+		if err != nil || proxyURL == nil {
+			return "not set, using HTTPS_PROXY, HTTP_PROXY and NO_PROXY", err
+		}
+		return proxyURL.Redacted(), nil
+	}},
+	{"agent_id_pattern", func(cfg *config.Config) (string, error) {
+		if cfg.AgentIDPattern == "" {
+			return "not set, agent IDs aren't checked", nil
+		}
+		if _, err := regexp.Compile(cfg.AgentIDPattern); err != nil {
+			return "", err
+		}
+		return cfg.AgentIDPattern, nil
+	}},
+	{"default_agent_id", func(cfg *config.Config) (string, error) {
+		if cfg.DefaultAgentID == "" {
+			return "not set, create commands need --agent-id", nil
+		}
+		if err := validateAgentID(cfg, cfg.DefaultAgentID); err != nil {
+			return "", err
+		}
+		return cfg.DefaultAgentID, nil
+	}},
+	{"roles", func(cfg *config.Config) (string, error) {
+		if err := validateRoleAliases(cfg); err != nil {
+			return "", err
+		}
+		if _, err := resolveRole(cfg, cfg.RecurringDefaultRole); err != nil {
+			return "", fmt.Errorf("invalid recurring_default_role: %w", err)
+		}
+		if _, err := resolveRole(cfg, cfg.OneTimeDefaultRole); err != nil {
+			return "", fmt.Errorf("invalid onetime_default_role: %w", err)
+		}
+		return fmt.Sprintf("recurring %s, one-time %s", cfg.RecurringDefaultRole, cfg.OneTimeDefaultRole), nil
+	}},
+}
+
+var validateConfigCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration without contacting the server",
+	Long: `Check the resolved configuration offline: that the config file parses,
+base_url is a valid http(s) URL, an API key is set and looks plausible, and
+the timezone, duration, retry and role settings are valid. Each check is
+reported and the command exits non-zero if any fails, e.g. as a CI preflight.
+
+A config file that can't be parsed at all is reported when the CLI starts.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := configStore.Path()
+		if _, err := os.Stat(path); err == nil {
+			fmt.Printf("✓ config file: %s\n", path)
+		} else {
+			fmt.Printf("✓ config file: %s not found, using defaults\n", path)
+		}
+
+		cfg, err := configStore.Load()
+		if err != nil {
+			color.Red("✗ settings: %v", err)
+			return fmt.Errorf("config is invalid")
+		}
+
+		failed := 0
+		for _, c := range configChecks {
+			detail, err := c.Check(cfg)
+			if err != nil {
+				failed++
+				color.Red("✗ %s: %v", c.Name, err)
+				continue
+			}
+			fmt.Printf("✓ %s: %s\n", c.Name, detail)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d config checks failed", failed, len(configChecks))
+		}
+		color.Green("\n✓ Config is valid")
+		return nil
+	},
+}
+
+// checkBaseURLs checks that every endpoint is an absolute http or https URL
+func checkBaseURLs(cfg *config.Config) (string, error) {
+	endpoints := cfg.Endpoints()
+	for _, endpoint := range endpoints {
+		if endpoint == "" {
+			return "", fmt.Errorf("not set. Run 'letta-switchboard config set-url <url>'")
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return "", fmt.Errorf("invalid URL %q: %w", endpoint, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("invalid URL %q: expected http:// or https:// and a host", endpoint)
+		}
+	}
+	return strings.Join(endpoints, ", "), nil
+}
+
+// checkAPIKey checks that an API key is set and looks like one, without
+// showing it
+func checkAPIKey(cfg *config.Config) (string, error) {
+	key := cfg.APIKey
+	switch {
+	case key == "":
+		return "", fmt.Errorf("not set. Run 'letta-switchboard config set-api-key <key>'")
+	case strings.IndexFunc(key, func(r rune) bool { return r <= ' ' || r == 0x7f }) != -1:
+		return "", fmt.Errorf("contains whitespace or control characters; check for a stray newline or quote")
+	case len(key) < minAPIKeyLength:
+		return "", fmt.Errorf("only %d characters long; expected at least %d", len(key), minAPIKeyLength)
+	}
+	return fmt.Sprintf("set (%d characters)", len(key)), nil
+}
+
+// checkDurations checks that no duration setting is negative
+func checkDurations(cfg *config.Config) (string, error) {
+	durations := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"timeout", cfg.Timeout},
+		{"cache_ttl", cfg.CacheTTL},
+		{"retry_max_elapsed", cfg.RetryMaxElapsed},
+		{"min_cron_interval", cfg.MinCronInterval},
+	}
+	var parts []string
+	for _, d := range durations {
+		if d.value < 0 {
+			return "", fmt.Errorf("%s must not be negative, got %s", d.key, d.value)
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", d.key, d.value))
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+func init() {
+	configCmd.AddCommand(validateConfigCmd)
+}
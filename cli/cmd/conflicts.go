@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// flagConflicts lists pairs of flags that can't be used together. Every
+// command is checked before it runs, so add new pairs here rather than
+// letting one flag silently win over the other.
+var flagConflicts = [][2]string{
+	{"cache", "no-cache"},
+	{"api-key", "api-key-file"},
+	{"fail-fast", "continue-on-error"},
+	{"output", "template-file"},
+}
+
+// checkFlagConflicts returns an error naming the first pair of conflicting
+// flags set on cmd
+func checkFlagConflicts(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	for _, pair := range flagConflicts {
+		a, b := flags.Lookup(pair[0]), flags.Lookup(pair[1])
+		if a != nil && b != nil && a.Changed && b.Changed {
+			return fmt.Errorf("--%s and --%s can't be used together", pair[0], pair[1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/letta/letta-switchboard-cli/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var recurringDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare a batch file with the live recurring schedules",
+	Long: `Show what apply would do with the recurring entries of a batch file
+without changing anything. Apply never updates a live schedule: it skips
+entries that exactly match one, like recurring ensure, and creates the rest.
+Each entry is matched to a live schedule by agent and name, or by agent,
+cron and message when it has no name, and reported as:
+
+  + add        no live schedule matches, so apply creates it
+  = unchanged  a live schedule matches exactly, so apply skips the entry
+  ! duplicate  a live schedule matches but differs, so apply creates a
+               second one next to it; the fields where the file differs are
+               listed, and apply leaves the live one as it is
+
+Exits non-zero when any entry would be added or duplicated, so CI can fail
+on drift. One-time entries are skipped, and live schedules
+missing from the file are not reported, since apply never deletes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		dialectName, _ := cmd.Flags().GetString("cron-dialect")
+		strictMessage, _ := cmd.Flags().GetBool("strict-message")
+		if file == "" {
+			return fmt.Errorf("--file is required")
+		}
+		dialect, err := parser.ParseCronDialect(dialectName)
+		if err != nil {
+			return err
+		}
+		opts := applyOptions{AllowPast: true, Dialect: dialect, StrictMessage: strictMessage, Force: true}
+
+		data, err := readApplyFile(file)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		entries, err := parseApplyFile(cfg, data)
+		if err != nil {
+			return err
+		}
+		plan, err := planApply(cfg, entries, opts)
+		if err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		live, err := apiClient.ListRecurringSchedules()
+		if err != nil {
+			return fmt.Errorf("failed to list schedules: %w", err)
+		}
+
+		var added, unchanged, duplicates, skipped int
+		matched := make(map[string]bool)
+		for _, p := range plan {
+			if p.Recurring == nil {
+				skipped++
+				continue
+			}
+
+			want := p.Recurring
+			existing := matchLiveSchedule(live, want, matched)
+			if existing == nil {
+				added++
+				color.Green("+ entry %d: add %s", p.Index, describeWanted(want))
+				continue
+			}
+			matched[existing.ID] = true
+
+			diffs := recurringFieldDiffs(existing, want)
+			if len(diffs) == 0 {
+				unchanged++
+				fmt.Printf("= entry %d: unchanged %s\n", p.Index, existing.ID)
+				continue
+			}
+			duplicates++
+			color.Yellow("! entry %d: duplicate of %s", p.Index, existing.ID)
+			for _, d := range diffs {
+				fmt.Printf("    %s\n", d)
+			}
+		}
+
+		fmt.Printf("\n%d to add, %d unchanged, %d duplicating a live schedule with differences", added, unchanged, duplicates)
+		if skipped > 0 {
+			fmt.Printf(", %d one-time skipped", skipped)
+		}
+		fmt.Println()
+		if duplicates > 0 {
+			fmt.Println("apply doesn't update live schedules: it would create the duplicates next to them, so change or delete the live ones first")
+		}
+
+		if drift := added + duplicates; drift > 0 {
+			return fmt.Errorf("%d of %d recurring entries in %s are missing from or differ from the live schedules", drift, added+unchanged+duplicates, file)
+		}
+		return nil
+	},
+}
+
+// matchLiveSchedule finds the live schedule an entry corresponds to: the one
+// with the same agent and name when the entry has a name, otherwise one with
+// the same agent, cron and message. Schedules already matched to an earlier entry are
+// passed over.
+func matchLiveSchedule(live []client.RecurringSchedule, want *client.RecurringScheduleCreate, matched map[string]bool) *client.RecurringSchedule {
+	for i := range live {
+		s := &live[i]
+		if matched[s.ID] {
+			continue
+		}
+		if want.Name != "" {
+			if s.AgentID == want.AgentID && s.Name == want.Name {
+				return s
+			}
+			continue
+		}
+		if s.AgentID == want.AgentID && s.Message == want.Message && sameCron(s.CronString, want.CronString) {
+			return s
+		}
+	}
+	return nil
+}
+
+// recurringFieldDiffs lists the fields where the file differs from a live
+// schedule, as `field: "live" → "file"` lines
+func recurringFieldDiffs(s *client.RecurringSchedule, want *client.RecurringScheduleCreate) []string {
+	var diffs []string
+	field := func(name, old, new string) {
+		if old != new {
+			diffs = append(diffs, fmt.Sprintf("%s: %q → %q", name, old, new))
+		}
+	}
+
+	if !sameCron(s.CronString, want.CronString) {
+		field("cron", s.CronString, want.CronString)
+	}
+	field("message", s.Message, want.Message)
+	field("role", s.Role, want.Role)
+	field("description", s.Description, want.Description)
+	field("tags", joinSortedTags(s.Tags), joinSortedTags(want.Tags))
+	return diffs
+}
+
+// describeWanted summarizes a schedule that doesn't exist yet
+func describeWanted(want *client.RecurringScheduleCreate) string {
+	if want.Name != "" {
+		return fmt.Sprintf("%q for %s (%s)", want.Name, want.AgentID, want.CronString)
+	}
+	return fmt.Sprintf("schedule for %s (%s)", want.AgentID, want.CronString)
+}
+
+// joinSortedTags renders tags in a fixed order so ordering differences
+// don't count as changes
+func joinSortedTags(tags []string) string {
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+func init() {
+	recurringCmd.AddCommand(recurringDiffCmd)
+	recurringDiffCmd.Flags().StringP("file", "f", "", "Batch file of schedules (YAML or JSON), or - for stdin")
+	addStrictMessageFlag(recurringDiffCmd)
+	addCronDialectFlag(recurringDiffCmd)
+}
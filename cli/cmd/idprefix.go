@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+)
+
+// fullIDPattern matches a complete schedule ID, a UUID. Anything else given
+// to get and delete is treated as an ID prefix or a schedule name.
+var fullIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// resolveRecurringID expands a recurring schedule ID prefix or name to the
+// full ID, listing schedules to find it
+func resolveRecurringID(apiClient *client.Client, ref string) (string, error) {
+	if fullIDPattern.MatchString(ref) {
+		return ref, nil
+	}
+	schedules, err := apiClient.ListRecurringSchedules()
+	if err != nil {
+		return "", fmt.Errorf("failed to list schedules: %w", err)
+	}
+	ids := make([]string, len(schedules))
+	names := make([]string, len(schedules))
+	for i, s := range schedules {
+		ids[i], names[i] = s.ID, s.Name
+	}
+	return matchScheduleRef("recurring", ref, ids, names)
+}
+
+// resolveOneTimeID expands a one-time schedule ID prefix or name to the full
+// ID, listing schedules to find it
+func resolveOneTimeID(apiClient *client.Client, ref string) (string, error) {
+	if fullIDPattern.MatchString(ref) {
+		return ref, nil
+	}
+	schedules, err := apiClient.ListOneTimeSchedules()
+	if err != nil {
+		return "", fmt.Errorf("failed to list schedules: %w", err)
+	}
+	ids := make([]string, len(schedules))
+	names := make([]string, len(schedules))
+	for i, s := range schedules {
+		ids[i], names[i] = s.ID, s.Name
+	}
+	return matchScheduleRef("one-time", ref, ids, names)
+}
+
+// matchScheduleRef returns the one ID in ids that ref refers to: the ID
+// itself, the only ID starting with ref, or else the only schedule named ref
+// (ignoring case). names[i] is the name of ids[i]. No match or several are
+// errors, the latter listing the candidates, and so is a ref that is both an
+// ID prefix and the name of a different schedule, rather than picking one.
+func matchScheduleRef(kind, ref string, ids, names []string) (string, error) {
+	if ref == "" {
+		return "", fmt.Errorf("schedule ID is required")
+	}
+
+	var prefixMatches, nameMatches []string
+	for i, id := range ids {
+		if id == ref {
+			return id, nil
+		}
+		if strings.HasPrefix(id, ref) {
+			prefixMatches = append(prefixMatches, id)
+		}
+		if names[i] != "" && strings.EqualFold(names[i], ref) {
+			nameMatches = append(nameMatches, id)
+		}
+	}
+
+	if len(prefixMatches) > 0 && len(nameMatches) > 0 {
+		if len(prefixMatches) == 1 && len(nameMatches) == 1 && prefixMatches[0] == nameMatches[0] {
+			return prefixMatches[0], nil
+		}
+		return "", fmt.Errorf("%q is ambiguous, use the full ID:\n  %s schedule IDs starting with it: %s\n  %s schedules named %q: %s", ref, kind, strings.Join(prefixMatches, ", "), kind, ref, strings.Join(nameMatches, ", "))
+	}
+
+	switch len(prefixMatches) {
+	case 0:
+	case 1:
+		return prefixMatches[0], nil
+	default:
+		return "", fmt.Errorf("ID prefix %q matches %d %s schedules, use more characters:\n  %s", ref, len(prefixMatches), kind, strings.Join(prefixMatches, "\n  "))
+	}
+
+	switch len(nameMatches) {
+	case 0:
+		return "", fmt.Errorf("no %s schedule ID starts with %q, and no schedule has that name", kind, ref)
+	case 1:
+		return nameMatches[0], nil
+	default:
+		return "", fmt.Errorf("%d %s schedules are named %q, use the ID:\n  %s", len(nameMatches), kind, ref, strings.Join(nameMatches, "\n  "))
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchScheduleRef(t *testing.T) {
+	ids := []string{"abc12345", "abd67890", "fed00001", "cafe0002", "beef0003"}
+	names := []string{"nightly", "", "abc", "Digest", "digest"}
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "abd67890", want: "abd67890"},
+		{ref: "abd", want: "abd67890"},
+		{ref: "ab", wantErr: "matches 2 recurring schedules"},
+		{ref: "nightly", want: "abc12345"},
+		{ref: "NIGHTLY", want: "abc12345"},
+		{ref: "digest", wantErr: "2 recurring schedules are named"},
+		{ref: "missing", wantErr: "no recurring schedule ID starts with"},
+		{ref: "", wantErr: "schedule ID is required"},
+		// "abc" starts one ID and names a different schedule
+		{ref: "abc", wantErr: "ambiguous"},
+		// "fed" is a prefix only; the schedule named "abc" doesn't interfere
+		{ref: "fed", want: "fed00001"},
+	}
+
+	for _, tt := range tests {
+		got, err := matchScheduleRef("recurring", tt.ref, ids, names)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("matchScheduleRef(%q) = %q, %v; want an error containing %q", tt.ref, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("matchScheduleRef(%q) = %q, %v; want %q", tt.ref, got, err, tt.want)
+		}
+	}
+}
+
+func TestMatchScheduleRefSameScheduleByPrefixAndName(t *testing.T) {
+	got, err := matchScheduleRef("one-time", "cafe", []string{"cafe0001", "beef0002"}, []string{"cafe", ""})
+	if err != nil || got != "cafe0001" {
+		t.Errorf("matchScheduleRef = %q, %v; want cafe0001", got, err)
+	}
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// scheduleItem is a recurring or one-time schedule in the combined list
+type scheduleItem struct {
+	Type      string          `json:"type"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name,omitempty"`
+	AgentID   string          `json:"agent_id"`
+	Message   string          `json:"message"`
+	Role      string          `json:"role"`
+	Cron      string          `json:"cron,omitempty"`
+	ExecuteAt string          `json:"execute_at,omitempty"`
+	Tags      []string        `json:"tags,omitempty"`
+	Owner     string          `json:"owner,omitempty"`
+	Team      string          `json:"team,omitempty"`
+	CreatedAt client.FlexTime `json:"created_at"`
+}
+
+// When returns the cron expression or execution time of the schedule
+func (s scheduleItem) When() string {
+	if s.Cron != "" {
+		return s.Cron
+	}
+	return s.ExecuteAt
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring and one-time schedules together",
+	Long:  "List both recurring and one-time schedules in a single table",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := getListOptions(cmd)
+		if err != nil {
+			return err
+		}
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		if groupBy != "" && groupBy != groupByAgent {
+			return fmt.Errorf("invalid --group-by value: %s (expected %s)", groupBy, groupByAgent)
+		}
+		if groupBy != "" && !isTable(opts.Output) && opts.Output != outputJSON {
+			return fmt.Errorf("--group-by works with table, wide, and json output, not %s", opts.Output)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		loc, err := displayLocation(cfg)
+		if err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		apiClient.Scope = opts.scope()
+		return watchList(cmd, func() error {
+			recurring, err := apiClient.ListRecurringSchedules()
+			if err != nil {
+				return fmt.Errorf("failed to list recurring schedules: %w", err)
+			}
+			onetime, err := apiClient.ListOneTimeSchedules()
+			if err != nil {
+				return fmt.Errorf("failed to list one-time schedules: %w", err)
+			}
+
+			if !opts.scoped() {
+				storeCache(cmd, cfg, recurringCacheKey, recurring)
+				storeCache(cmd, cfg, onetimeCacheKey, onetime)
+			}
+			opts.warnUnscoped(recurring, onetime)
+
+			items := mergeSchedules(filterRecurring(recurring, opts), filterOneTime(onetime, opts))
+			sortScheduleItems(items, opts.Sort)
+
+			if len(items) == 0 && isTable(opts.Output) {
+				fmt.Println("No schedules found")
+				return nil
+			}
+
+			if groupBy == groupByAgent {
+				return renderGroupedByAgent(opts.Output, loc, items)
+			}
+
+			rows := [][]string{}
+			for _, s := range items {
+				rows = append(rows, scheduleItemRow(loc, s))
+			}
+			return renderList(opts.Output, scheduleItemColumns, rows, items)
+		})
+	},
+}
+
+// scheduleItemColumns are the columns of the combined list, matching
+// scheduleItemRow
+var scheduleItemColumns = []column{
+	{Header: "Type"},
+	{Header: "Schedule ID"},
+	{Header: "Name"},
+	{Header: "Agent ID"},
+	{Header: "Schedule"},
+	{Header: "Message"},
+	{Header: "Tags"},
+	{Header: "Age"},
+	{Header: "Role", Wide: true},
+	{Header: "Owner", Wide: true},
+	{Header: "Created At", Wide: true},
+}
+
+// scheduleItemRow returns the table cells for one schedule in the combined list
+func scheduleItemRow(loc *time.Location, s scheduleItem) []string {
+	return []string{
+		s.Type,
+		s.ID,
+		orDash(s.Name),
+		s.AgentID,
+		formatTime(loc, s.When()),
+		s.Message,
+		orDash(strings.Join(s.Tags, ",")),
+		formatAge(s.CreatedAt, time.Now()),
+		s.Role,
+		orDash(s.Owner),
+		formatFlexTime(loc, s.CreatedAt),
+	}
+}
+
+// mergeSchedules combines both schedule types into one list, recurring first
+func mergeSchedules(recurring []client.RecurringSchedule, onetime []client.OneTimeSchedule) []scheduleItem {
+	items := []scheduleItem{}
+	for _, s := range recurring {
+		items = append(items, scheduleItem{
+			Type:      "recurring",
+			ID:        s.ID,
+			Name:      s.Name,
+			AgentID:   s.AgentID,
+			Message:   s.Message,
+			Role:      s.Role,
+			Cron:      s.CronString,
+			Tags:      s.Tags,
+			Owner:     s.Owner,
+			Team:      s.Team,
+			CreatedAt: s.CreatedAt,
+		})
+	}
+	for _, s := range onetime {
+		items = append(items, scheduleItem{
+			Type:      "one-time",
+			ID:        s.ID,
+			Name:      s.Name,
+			AgentID:   s.AgentID,
+			Message:   s.Message,
+			Role:      s.Role,
+			ExecuteAt: s.ExecuteAt,
+			Tags:      s.Tags,
+			Owner:     s.Owner,
+			Team:      s.Team,
+			CreatedAt: s.CreatedAt,
+		})
+	}
+	return items
+}
+
+// sortScheduleItems orders the merged list; the per-type lists are already
+// sorted, but merging needs a second pass to interleave them
+func sortScheduleItems(items []scheduleItem, by string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch by {
+		case sortByID:
+			return a.ID < b.ID
+		case sortByAgent:
+			return a.AgentID < b.AgentID
+		case sortByCreated:
+			return a.CreatedAt.Before(b.CreatedAt.Time)
+		}
+		return false
+	})
+}
+
+const groupByAgent = "agent"
+
+// agentGroup is one agent's schedules in --group-by agent output
+type agentGroup struct {
+	AgentID   string         `json:"agent_id"`
+	Recurring int            `json:"recurring"`
+	OneTime   int            `json:"one_time"`
+	Schedules []scheduleItem `json:"schedules"`
+}
+
+// groupItemsByAgent collects schedules per agent, with agents in ID order and
+// each agent's schedules kept in list order
+func groupItemsByAgent(items []scheduleItem) []agentGroup {
+	index := map[string]int{}
+	groups := []agentGroup{}
+	for _, s := range items {
+		i, ok := index[s.AgentID]
+		if !ok {
+			i = len(groups)
+			index[s.AgentID] = i
+			groups = append(groups, agentGroup{AgentID: s.AgentID})
+		}
+
+		g := &groups[i]
+		g.Schedules = append(g.Schedules, s)
+		if s.Cron != "" {
+			g.Recurring++
+		} else {
+			g.OneTime++
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].AgentID < groups[j].AgentID
+	})
+	return groups
+}
+
+// renderGroupedByAgent writes the combined list as a heading with counts for
+// each agent followed by an indented table of its schedules, or as JSON
+// groups
+func renderGroupedByAgent(format string, loc *time.Location, items []scheduleItem) error {
+	groups := groupItemsByAgent(items)
+	if format == outputJSON {
+		return printJSON(groups)
+	}
+
+	// The heading names the agent, so the tables leave out its column
+	var columns []column
+	agentColumn := -1
+	for i, c := range scheduleItemColumns {
+		if c.Header == "Agent ID" {
+			agentColumn = i
+			continue
+		}
+		columns = append(columns, c)
+	}
+
+	heading := color.New(color.Bold)
+	out := &indentWriter{w: os.Stdout, prefix: "  "}
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s (%d recurring, %d one-time)\n", heading.Sprint(g.AgentID), g.Recurring, g.OneTime)
+
+		rows := [][]string{}
+		for _, s := range g.Schedules {
+			row := scheduleItemRow(loc, s)
+			rows = append(rows, append(row[:agentColumn:agentColumn], row[agentColumn+1:]...))
+		}
+		renderTable(out, format == outputWide, columns, rows, nil)
+	}
+
+	fmt.Printf("\n%d schedules across %d agents\n", len(items), len(groups))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	addListFlags(listCmd)
+	listCmd.Flags().String("group-by", "", "Group schedules under each agent: "+groupByAgent)
+}
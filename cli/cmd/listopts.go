@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sortByID      = "id"
+	sortByAgent   = "agent"
+	sortByCreated = "created"
+)
+
+var sortKeys = []string{sortByID, sortByAgent, sortByCreated}
+
+// listOptions holds the filter, sort, and output flags shared by the list commands
+type listOptions struct {
+	AgentID string
+	Tags    []string
+	Sort    string
+	Output  string
+	Owner   string
+	Team    string
+}
+
+// addListFlags registers the shared list flags on a command
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().String("agent-id", "", "Only show schedules for this agent, or for agents matching a glob like 'agent-team-*'")
+	addTagFlag(cmd, "Only show schedules with this tag (repeatable; all must match)")
+	cmd.Flags().String("owner", "", "Only show schedules owned by this user (needs server support)")
+	cmd.Flags().String("team", "", "Only show schedules of this team (needs server support)")
+	cmd.Flags().String("sort", "", "Sort by field: "+strings.Join(sortKeys, ", "))
+	addOutputFlag(cmd)
+	addWatchFlags(cmd)
+}
+
+// getListOptions reads and validates the shared list flags
+func getListOptions(cmd *cobra.Command) (*listOptions, error) {
+	agentID, _ := cmd.Flags().GetString("agent-id")
+	sortBy, _ := cmd.Flags().GetString("sort")
+	owner, _ := cmd.Flags().GetString("owner")
+	team, _ := cmd.Flags().GetString("team")
+
+	if isAgentGlob(agentID) {
+		if _, err := path.Match(agentID, ""); err != nil {
+			return nil, fmt.Errorf("invalid --agent-id pattern %q: %w", agentID, err)
+		}
+	}
+
+	sortBy = strings.ToLower(sortBy)
+	if sortBy != "" && !contains(sortKeys, sortBy) {
+		return nil, fmt.Errorf("invalid sort field: %s (expected one of: %s)", sortBy, strings.Join(sortKeys, ", "))
+	}
+
+	tags, err := getTags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := getOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listOptions{
+		AgentID: agentID,
+		Tags:    tags,
+		Sort:    sortBy,
+		Output:  output,
+		Owner:   strings.TrimSpace(owner),
+		Team:    strings.TrimSpace(team),
+	}, nil
+}
+
+// matchAgent reports whether a schedule for agentID passes the --agent-id
+// filter: an exact ID, or a glob when it contains * ? or [
+func (o *listOptions) matchAgent(agentID string) bool {
+	if o.AgentID == "" || o.AgentID == agentID {
+		return true
+	}
+	if !isAgentGlob(o.AgentID) {
+		return false
+	}
+	matched, _ := path.Match(o.AgentID, agentID)
+	return matched
+}
+
+// isAgentGlob reports whether an --agent-id filter is a glob pattern rather
+// than an exact ID
+func isAgentGlob(agentID string) bool {
+	return strings.ContainsAny(agentID, "*?[")
+}
+
+// match reports whether a schedule passes the --agent-id and --tag filters
+func (o *listOptions) match(agentID string, tags []string) bool {
+	return o.matchAgent(agentID) && hasTags(tags, o.Tags)
+}
+
+// scope returns the --owner and --team filters to send with list requests
+func (o *listOptions) scope() client.ListScope {
+	return client.ListScope{Owner: o.Owner, Team: o.Team}
+}
+
+// scoped reports whether --owner or --team was given
+func (o *listOptions) scoped() bool {
+	return o.Owner != "" || o.Team != ""
+}
+
+// matchScope reports whether a schedule passes the --owner and --team
+// filters. The server applies them; this only drops schedules it reports
+// with a different owner or team, so a schedule without one always passes.
+func (o *listOptions) matchScope(owner, team string) bool {
+	return (o.Owner == "" || owner == "" || owner == o.Owner) &&
+		(o.Team == "" || team == "" || team == o.Team)
+}
+
+// warnUnscoped warns when --owner or --team was given but none of the
+// listed schedules reports an owner or team, which suggests the server
+// ignored the filters
+func (o *listOptions) warnUnscoped(recurring []client.RecurringSchedule, onetime []client.OneTimeSchedule) {
+	if !o.scoped() || len(recurring)+len(onetime) == 0 {
+		return
+	}
+	for _, s := range recurring {
+		if s.Owner != "" || s.Team != "" {
+			return
+		}
+	}
+	for _, s := range onetime {
+		if s.Owner != "" || s.Team != "" {
+			return
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Warning: the server didn't report schedule owners or teams, so --owner and --team may not have filtered anything")
+}
+
+// printOwnerLines prints the owner and team in get output, for servers that
+// report them
+func printOwnerLines(owner, team string) {
+	if owner != "" {
+		fmt.Printf("Owner:        %s\n", owner)
+	}
+	if team != "" {
+		fmt.Printf("Team:         %s\n", team)
+	}
+}
+
+// filterRecurring applies the list filters and sort order to recurring schedules
+func filterRecurring(schedules []client.RecurringSchedule, opts *listOptions) []client.RecurringSchedule {
+	filtered := []client.RecurringSchedule{}
+	for _, s := range schedules {
+		if opts.match(s.AgentID, s.Tags) && opts.matchScope(s.Owner, s.Team) {
+			filtered = append(filtered, s)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		switch opts.Sort {
+		case sortByID:
+			return a.ID < b.ID
+		case sortByAgent:
+			return a.AgentID < b.AgentID
+		case sortByCreated:
+			return a.CreatedAt.Before(b.CreatedAt.Time)
+		}
+		return false
+	})
+
+	return filtered
+}
+
+// filterOneTime applies the list filters and sort order to one-time schedules
+func filterOneTime(schedules []client.OneTimeSchedule, opts *listOptions) []client.OneTimeSchedule {
+	filtered := []client.OneTimeSchedule{}
+	for _, s := range schedules {
+		if opts.match(s.AgentID, s.Tags) && opts.matchScope(s.Owner, s.Team) {
+			filtered = append(filtered, s)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		switch opts.Sort {
+		case sortByID:
+			return a.ID < b.ID
+		case sortByAgent:
+			return a.AgentID < b.AgentID
+		case sortByCreated:
+			return a.CreatedAt.Before(b.CreatedAt.Time)
+		}
+		return false
+	})
+
+	return filtered
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+// ansiEscapePattern matches terminal escape sequences such as color codes,
+// which are removed whole rather than leaving "[31m" behind
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// cleanMessage removes control characters other than newlines and tabs from
+// a message, such as NUL bytes or color codes picked up when pasting. Windows
+// line endings become plain newlines. Removals are reported as a warning, or
+// as an error when strict is set.
+func cleanMessage(message string, strict bool) (string, error) {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	removed := len(ansiEscapePattern.FindAllString(message, -1))
+	message = ansiEscapePattern.ReplaceAllString(message, "")
+
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || !unicode.IsControl(r) {
+			return r
+		}
+		removed++
+		return -1
+	}, message)
+
+	if removed == 0 {
+		return message, nil
+	}
+	if strict {
+		return "", fmt.Errorf("message contains %d control character(s); only newlines and tabs are allowed", removed)
+	}
+	if strings.TrimSpace(cleaned) == "" {
+		return "", fmt.Errorf("message is empty once control characters are removed")
+	}
+	fmt.Fprintf(os.Stderr, "Warning: removed %d control character(s) from the message\n", removed)
+	return cleaned, nil
+}
+
+// addStrictMessageFlag registers --strict-message on a command
+func addStrictMessageFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("strict-message", false, "Reject messages containing control characters instead of removing them")
+}
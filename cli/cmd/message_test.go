@@ -0,0 +1,57 @@
+package cmd
+
+import "testing"
+
+func TestCleanMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "Hello! How are you?", "Hello! How are you?"},
+		{"newlines and tabs kept", "line one\n\tindented", "line one\n\tindented"},
+		{"unicode kept", "café ☕ — 日本語", "café ☕ — 日本語"},
+		{"windows line endings", "one\r\ntwo\r\n", "one\ntwo\n"},
+		{"stray carriage return", "one\rtwo", "onetwo"},
+		{"NUL bytes", "hel\x00lo\x00", "hello"},
+		{"bell and backspace", "ding\x07 oops\x08", "ding oops"},
+		{"delete", "abc\x7f", "abc"},
+		{"C1 control", "next\u0085line", "nextline"},
+		{"color codes", "\x1b[31mred\x1b[0m and \x1b[1;32mbold green\x1b[m", "red and bold green"},
+		{"cursor movement", "\x1b[2Kcleared\x1b[?25h", "cleared"},
+		{"bare escape", "esc\x1b here", "esc here"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cleanMessage(tt.input, false)
+			if err != nil {
+				t.Fatalf("cleanMessage(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("cleanMessage(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanMessageStrict(t *testing.T) {
+	// Windows line endings aren't control characters to reject
+	if got, err := cleanMessage("one\r\ntwo", true); err != nil || got != "one\ntwo" {
+		t.Errorf("cleanMessage with CRLF in strict mode = %q, %v; want %q", got, err, "one\ntwo")
+	}
+
+	for _, input := range []string{"hel\x00lo", "\x1b[31mred\x1b[0m", "one\rtwo"} {
+		if got, err := cleanMessage(input, true); err == nil {
+			t.Errorf("cleanMessage(%q) in strict mode = %q, want an error", input, got)
+		}
+	}
+}
+
+func TestCleanMessageOnlyControlCharacters(t *testing.T) {
+	for _, input := range []string{"\x00\x00", "\x1b[31m\x1b[0m", "\x07 \n"} {
+		if got, err := cleanMessage(input, false); err == nil {
+			t.Errorf("cleanMessage(%q) = %q, want an error", input, got)
+		}
+	}
+}
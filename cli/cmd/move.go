@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var recurringMoveCmd = &cobra.Command{
+	Use:   "move [schedule-id]",
+	Short: "Move a recurring schedule to another agent",
+	Long: `Point a recurring schedule, by ID or name, at a different agent without
+recreating it, so it keeps its ID, cron and message. When the server sends
+an ETag, the update is conditional on it, so a schedule someone else changed
+in the meantime is left alone.
+
+Needs a server that supports updating schedules (PATCH /schedules/recurring/{id}).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, to, err := moveTarget(cmd)
+		if err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		scheduleID, err := resolveRecurringID(apiClient, args[0])
+		if err != nil {
+			return err
+		}
+		schedule, err := apiClient.GetRecurringSchedule(scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule.AgentID == to {
+			fmt.Printf("Schedule %s is already on agent %s\n", scheduleID, to)
+			return nil
+		}
+
+		moved, err := apiClient.UpdateRecurringSchedule(scheduleID, client.RecurringScheduleUpdate{AgentID: to, IfMatch: schedule.ETag})
+		if err != nil {
+			return fmt.Errorf("failed to move schedule: %w", err)
+		}
+		invalidateCache(cfg, recurringCacheKey)
+		return reportMove(scheduleID, schedule.AgentID, to, moved.AgentID)
+	},
+}
+
+var onetimeMoveCmd = &cobra.Command{
+	Use:   "move [schedule-id]",
+	Short: "Move a one-time schedule to another agent",
+	Long: `Point a pending one-time schedule, by ID or name, at a different agent
+without recreating it, so it keeps its ID, time and message. Like recurring
+move, the update is conditional on the server's ETag when it sends one.
+
+Needs a server that supports updating schedules (PATCH /schedules/one-time/{id}).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, to, err := moveTarget(cmd)
+		if err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		scheduleID, err := resolveOneTimeID(apiClient, args[0])
+		if err != nil {
+			return err
+		}
+		schedule, err := apiClient.GetOneTimeSchedule(scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule.AgentID == to {
+			fmt.Printf("Schedule %s is already on agent %s\n", scheduleID, to)
+			return nil
+		}
+
+		moved, err := apiClient.UpdateOneTimeSchedule(scheduleID, client.OneTimeScheduleUpdate{AgentID: to, IfMatch: schedule.ETag})
+		if err != nil {
+			return fmt.Errorf("failed to move schedule: %w", err)
+		}
+		invalidateCache(cfg, onetimeCacheKey)
+		return reportMove(scheduleID, schedule.AgentID, to, moved.AgentID)
+	},
+}
+
+// moveTarget loads the config and returns the validated --to agent ID
+func moveTarget(cmd *cobra.Command) (*config.Config, string, error) {
+	to, _ := cmd.Flags().GetString("to")
+	if to == "" {
+		return nil, "", fmt.Errorf("--to is required")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, "", err
+	}
+	if err := validateAgentID(cfg, to); err != nil {
+		return nil, "", err
+	}
+	return cfg, to, nil
+}
+
+// reportMove prints the agent before and after a move. A server that
+// accepts the update but ignores agent_id returns the old agent, which is
+// reported as a failure rather than a silent no-op.
+func reportMove(scheduleID, from, to, got string) error {
+	if got != to {
+		return fmt.Errorf("the server kept schedule %s on agent %s; it may not support changing agent_id", scheduleID, got)
+	}
+	color.Green("✓ Schedule %s moved", scheduleID)
+	fmt.Printf("Agent: %s → %s\n", from, to)
+	return nil
+}
+
+func init() {
+	recurringCmd.AddCommand(recurringMoveCmd)
+	onetimeCmd.AddCommand(onetimeMoveCmd)
+	for _, cmd := range []*cobra.Command{recurringMoveCmd, onetimeMoveCmd} {
+		cmd.Flags().String("to", "", "Agent ID to move the schedule to (required)")
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// addNameFlags registers --name and --description on a create command
+func addNameFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Short name for the schedule; get and delete accept it in place of the ID")
+	cmd.Flags().String("description", "", "Longer description of what the schedule is for")
+}
+
+// getNameFlags returns the trimmed --name and --description values
+func getNameFlags(cmd *cobra.Command) (string, string) {
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+	return strings.TrimSpace(name), strings.TrimSpace(description)
+}
+
+// printNameLines prints the name and description lines of get, skipping
+// unset ones
+func printNameLines(name, description string) {
+	if name != "" {
+		fmt.Printf("Name:         %s\n", name)
+	}
+	if description != "" {
+		fmt.Printf("Description:  %s\n", description)
+	}
+}
@@ -2,13 +2,11 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/letta/letta-switchboard-cli/internal/client"
-	"github.com/letta/letta-switchboard-cli/internal/config"
-	"github.com/letta/letta-switchboard-cli/internal/parser"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
@@ -20,18 +18,45 @@ var onetimeCmd = &cobra.Command{
 }
 
 var onetimeCreateCmd = &cobra.Command{
-	Use:     "create",
+	Use:     "create [agent-id] [execute-at] [message]",
 	Aliases: []string{"send"},
 	Short:   "Send a message to an agent",
-	Long:    "Send a message to an agent immediately or scheduled for later",
+	Long: `Send a message to an agent immediately or scheduled for later, from flags
+or from the shorthand positional arguments:
+
+  letta-switchboard onetime create agent-xxx "in 5 minutes" "Reminder: stand up"
+
+The agent ID can be left out when default_agent_id is set; use "now" as the
+time to send immediately. --agent-id, --execute-at and --message win over the
+positional values when both are given.`,
+	Args: cobra.MaximumNArgs(3),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyShorthandArgs(cmd, args, "execute-at"); err != nil {
+			return err
+		}
 		agentID, _ := cmd.Flags().GetString("agent-id")
 		message, _ := cmd.Flags().GetString("message")
 		role, _ := cmd.Flags().GetString("role")
 		executeAt, _ := cmd.Flags().GetString("execute-at")
+		allowPast, _ := cmd.Flags().GetBool("allow-past")
+		format, err := getCreateFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		if message == "" {
+			return fmt.Errorf("message is required")
+		}
+
+		strictMessage, _ := cmd.Flags().GetBool("strict-message")
+		message, err = cleanMessage(message, strictMessage)
+		if err != nil {
+			return err
+		}
 
-		if agentID == "" || message == "" {
-			return fmt.Errorf("agent-id and message are required")
+		tags, err := getTags(cmd)
+		if err != nil {
+			return err
 		}
 
 		// Default to "now" if no time specified
@@ -39,39 +64,68 @@ var onetimeCreateCmd = &cobra.Command{
 			executeAt = "now"
 		}
 
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
 		// Parse natural language time to ISO 8601
-		parsedTime, err := parser.ParseTime(executeAt)
+		parsedTime, err := parseUserTime(cfg, executeAt)
 		if err != nil {
 			return fmt.Errorf("failed to parse execute-at: %w", err)
 		}
-
-		cfg, err := config.Load()
+		if err := checkNotPast(parsedTime, allowPast); err != nil {
+			return err
+		}
+		agentID, err = resolveAgentID(cfg, agentID)
 		if err != nil {
 			return err
 		}
-		if err := cfg.Validate(); err != nil {
+		if role == "" {
+			role = cfg.OneTimeDefaultRole
+		}
+		role, err = resolveRole(cfg, role)
+		if err != nil {
 			return err
 		}
 
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
+		apiClient := newAPIClient(cmd, cfg)
+		name, description := getNameFlags(cmd)
 		schedule, err := apiClient.CreateOneTimeSchedule(client.OneTimeScheduleCreate{
-			AgentID:   agentID,
-			Message:   message,
-			Role:      role,
-			ExecuteAt: parsedTime,
+			AgentID:     agentID,
+			Name:        name,
+			Description: description,
+			Message:     message,
+			Role:        role,
+			ExecuteAt:   parsedTime,
+			Tags:        tags,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create schedule: %w", err)
 		}
+		invalidateCache(cfg, onetimeCacheKey)
 
+		if format == outputID {
+			fmt.Println(schedule.ID)
+			return nil
+		}
 		if executeAt == "now" {
 			color.Green("✓ Message sent successfully (executing immediately)")
 		} else {
 			color.Green("✓ Message scheduled successfully")
 		}
 		fmt.Printf("\nSchedule ID:  %s\n", schedule.ID)
+		if schedule.Name != "" {
+			fmt.Printf("Name:         %s\n", schedule.Name)
+		}
 		fmt.Printf("Agent ID:     %s\n", schedule.AgentID)
 		fmt.Printf("Execute At:   %s\n", schedule.ExecuteAt)
+		if len(schedule.Tags) > 0 {
+			fmt.Printf("Tags:         %s\n", strings.Join(schedule.Tags, ", "))
+		}
 		fmt.Printf("Message:      %s\n", schedule.Message)
 
 		return nil
@@ -82,50 +136,76 @@ var onetimeListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all one-time schedules",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		opts, err := getListOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
-
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
-		schedules, err := apiClient.ListOneTimeSchedules()
+		loc, err := displayLocation(cfg)
 		if err != nil {
-			return fmt.Errorf("failed to list schedules: %w", err)
+			return err
 		}
 
-		if len(schedules) == 0 {
-			fmt.Println("No one-time schedules found")
-			return nil
-		}
+		apiClient := newAPIClient(cmd, cfg)
+		apiClient.Scope = opts.scope()
+		return watchList(cmd, func() error {
+			schedules, err := apiClient.ListOneTimeSchedules()
+			if err != nil {
+				return fmt.Errorf("failed to list schedules: %w", err)
+			}
 
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Schedule ID", "Agent ID", "Execute At", "Message"})
-		table.SetAutoWrapText(false)
-		table.SetAutoFormatHeaders(true)
-		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-		table.SetAlignment(tablewriter.ALIGN_LEFT)
-		table.SetCenterSeparator("")
-		table.SetColumnSeparator("")
-		table.SetRowSeparator("")
-		table.SetHeaderLine(false)
-		table.SetBorder(false)
-		table.SetTablePadding("\t")
-		table.SetNoWhiteSpace(true)
+			// A scoped list is only part of what get commands look up
+			if !opts.scoped() {
+				storeCache(cmd, cfg, onetimeCacheKey, schedules)
+			}
+			opts.warnUnscoped(nil, schedules)
 
-		for _, s := range schedules {
-			table.Append([]string{
-				s.ID,
-				s.AgentID,
-				s.ExecuteAt,
-				truncate(s.Message, 50),
-			})
-		}
+			schedules = filterOneTime(schedules, opts)
+			if len(schedules) == 0 && isTable(opts.Output) {
+				fmt.Println("No one-time schedules found")
+				return nil
+			}
 
-		table.Render()
-		return nil
+			now := time.Now()
+			rows := [][]string{}
+			for _, s := range schedules {
+				rows = append(rows, []string{
+					s.ID,
+					orDash(s.Name),
+					s.AgentID,
+					formatTime(loc, s.ExecuteAt),
+					s.Message,
+					orDash(strings.Join(s.Tags, ",")),
+					formatAge(s.CreatedAt, now),
+					s.Role,
+					orDash(s.Owner),
+					orDash(s.Description),
+					formatFlexTime(loc, s.CreatedAt),
+				})
+			}
+
+			columns := []column{
+				{Header: "Schedule ID"},
+				{Header: "Name"},
+				{Header: "Agent ID"},
+				{Header: "Execute At"},
+				{Header: "Message"},
+				{Header: "Tags"},
+				{Header: "Age"},
+				{Header: "Role", Wide: true},
+				{Header: "Owner", Wide: true},
+				{Header: "Description", Wide: true},
+				{Header: "Created At", Wide: true},
+			}
+			return renderList(opts.Output, columns, rows, schedules)
+		})
 	},
 }
 
@@ -135,27 +215,67 @@ var onetimeGetCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		scheduleID := args[0]
+		format, err := getDetailFormat(cmd)
+		if err != nil {
+			return err
+		}
 
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
-
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
-		schedule, err := apiClient.GetOneTimeSchedule(scheduleID)
+		loc, err := displayLocation(cfg)
 		if err != nil {
-			return fmt.Errorf("failed to get schedule: %w", err)
+			return err
+		}
+
+		schedule := cachedOneTimeSchedule(cmd, cfg, scheduleID)
+		if schedule == nil {
+			apiClient := newAPIClient(cmd, cfg)
+			scheduleID, err = resolveOneTimeID(apiClient, scheduleID)
+			if err != nil {
+				return err
+			}
+			schedule, err = apiClient.GetOneTimeSchedule(scheduleID)
+			if err != nil {
+				return fmt.Errorf("failed to get schedule: %w", err)
+			}
+		}
+
+		switch format {
+		case outputJSON:
+			return printJSON(schedule)
+		case outputTemplate:
+			return renderTemplate(schedule)
+		case outputEnv:
+			printEnv([]envVar{
+				{"SCHEDULE_ID", schedule.ID},
+				{"NAME", schedule.Name},
+				{"DESCRIPTION", schedule.Description},
+				{"AGENT_ID", schedule.AgentID},
+				{"EXECUTE_AT", schedule.ExecuteAt},
+				{"MESSAGE", schedule.Message},
+				{"ROLE", schedule.Role},
+				{"TAGS", strings.Join(schedule.Tags, ",")},
+				{"CREATED_AT", schedule.CreatedAt.Format(time.RFC3339)},
+			})
+			return nil
 		}
 
 		fmt.Printf("Schedule ID:  %s\n", schedule.ID)
+		printNameLines(schedule.Name, schedule.Description)
 		fmt.Printf("Agent ID:     %s\n", schedule.AgentID)
-		fmt.Printf("Execute At:   %s\n", schedule.ExecuteAt)
+		fmt.Printf("Execute At:   %s\n", formatTime(loc, schedule.ExecuteAt))
 		fmt.Printf("Message:      %s\n", schedule.Message)
 		fmt.Printf("Role:         %s\n", schedule.Role)
-		fmt.Printf("Created At:   %s\n", schedule.CreatedAt.Format("2006-01-02 15:04:05"))
+		printOwnerLines(schedule.Owner, schedule.Team)
+		if len(schedule.Tags) > 0 {
+			fmt.Printf("Tags:         %s\n", strings.Join(schedule.Tags, ", "))
+		}
+		fmt.Printf("Created At:   %s\n", formatFlexTime(loc, schedule.CreatedAt))
 
 		return nil
 	},
@@ -164,11 +284,15 @@ var onetimeGetCmd = &cobra.Command{
 var onetimeDeleteCmd = &cobra.Command{
 	Use:   "delete [schedule-id]",
 	Short: "Delete a one-time schedule",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Delete a one-time schedule by ID, or all one-time schedules for an agent with --agent-id",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		scheduleID := args[0]
+		agentID, _ := cmd.Flags().GetString("agent-id")
+		if (len(args) == 0) == (agentID == "") {
+			return fmt.Errorf("specify either a schedule ID or --agent-id")
+		}
 
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
@@ -176,26 +300,172 @@ var onetimeDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
-		if err := apiClient.DeleteOneTimeSchedule(scheduleID); err != nil {
-			return fmt.Errorf("failed to delete schedule: %w", err)
+		apiClient := newAPIClient(cmd, cfg)
+
+		if agentID == "" {
+			scheduleID, err := resolveOneTimeID(apiClient, args[0])
+			if err != nil {
+				return err
+			}
+			if err := apiClient.DeleteOneTimeSchedule(scheduleID); err != nil {
+				return fmt.Errorf("failed to delete schedule: %w", err)
+			}
+			invalidateCache(cfg, onetimeCacheKey)
+
+			color.Green("✓ Schedule %s deleted successfully", scheduleID)
+			return nil
+		}
+
+		schedules, err := apiClient.ListOneTimeSchedules()
+		if err != nil {
+			return fmt.Errorf("failed to list schedules: %w", err)
+		}
+
+		var ids []string
+		for _, s := range schedules {
+			if s.AgentID == agentID {
+				ids = append(ids, s.ID)
+			}
+		}
+		if len(ids) == 0 {
+			fmt.Printf("No one-time schedules found for agent %s\n", agentID)
+			return nil
+		}
+
+		ok, err := confirmBulk(cmd, "delete", ids)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+
+		deleted, err := runBulk(ids, failFast(cmd), apiClient.DeleteOneTimeSchedule)
+		invalidateCache(cfg, onetimeCacheKey)
+		if err != nil {
+			return bulkFailure("deleted", deleted, len(ids), failFast(cmd), err)
 		}
 
-		color.Green("✓ Schedule deleted successfully")
+		color.Green("✓ Deleted %d schedules", deleted)
 		return nil
 	},
 }
 
+var onetimePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete one-time schedules whose execution time has passed",
+	Long: `Delete one-time schedules whose execution time is in the past, keeping the
+list focused on pending messages. With --executed-only, only schedules that
+have a recorded execution result are deleted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		executedOnly, _ := cmd.Flags().GetBool("executed-only")
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		schedules, err := apiClient.ListOneTimeSchedules()
+		if err != nil {
+			return fmt.Errorf("failed to list schedules: %w", err)
+		}
+
+		var executed map[string]bool
+		if executedOnly {
+			results, err := apiClient.ListResults()
+			if err != nil {
+				return fmt.Errorf("failed to list results: %w", err)
+			}
+			executed = map[string]bool{}
+			for _, r := range results {
+				executed[r.ScheduleID] = true
+			}
+		}
+
+		now := time.Now()
+		var ids []string
+		for _, s := range schedules {
+			t, ok := parseAPITime(s.ExecuteAt)
+			if !ok || !t.Before(now) {
+				continue
+			}
+			if executedOnly && !executed[s.ID] {
+				continue
+			}
+			ids = append(ids, s.ID)
+		}
+		if len(ids) == 0 {
+			fmt.Println("No past one-time schedules to prune")
+			return nil
+		}
+
+		ok, err := confirmBulk(cmd, "delete", ids)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+
+		pruned, err := runBulk(ids, failFast(cmd), apiClient.DeleteOneTimeSchedule)
+		invalidateCache(cfg, onetimeCacheKey)
+		if err != nil {
+			return bulkFailure("pruned", pruned, len(ids), failFast(cmd), err)
+		}
+
+		color.Green("✓ Pruned %d schedules", pruned)
+		return nil
+	},
+}
+
+// pastTolerance is how far in the past an execution time may be without
+// --allow-past, so "now" survives the trip to the server
+const pastTolerance = time.Minute
+
+// checkNotPast rejects execution times in the past unless allowPast is set
+func checkNotPast(executeAt string, allowPast bool) error {
+	if allowPast {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, executeAt)
+	if err != nil {
+		return nil
+	}
+	if t.Before(time.Now().Add(-pastTolerance)) {
+		return fmt.Errorf("execution time %s is in the past; pass --allow-past to schedule it anyway", executeAt)
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(onetimeCmd)
 
 	onetimeCmd.AddCommand(onetimeCreateCmd)
-	onetimeCreateCmd.Flags().String("agent-id", "", "Agent ID (required)")
+	onetimeCreateCmd.Flags().String("agent-id", "", "Agent ID (default default_agent_id from the config)")
 	onetimeCreateCmd.Flags().String("message", "", "Message to send (required)")
-	onetimeCreateCmd.Flags().String("role", "user", "Message role (default: user)")
+	addStrictMessageFlag(onetimeCreateCmd)
+	addNameFlags(onetimeCreateCmd)
+	onetimeCreateCmd.Flags().String("role", "", "Message role: user, system, assistant, or a role_aliases name (default: onetime_default_role from config, or user)")
 	onetimeCreateCmd.Flags().String("execute-at", "", "When to send (optional, defaults to now)\n  Examples: 'in 5 minutes', 'tomorrow at 9am', 'next monday at 3pm', '2025-11-07T10:00:00Z', or omit for immediate delivery")
+	onetimeCreateCmd.Flags().Bool("allow-past", false, "Allow an execution time in the past, e.g. '5 minutes ago', for backfill testing")
+	addTagFlag(onetimeCreateCmd, "Tag to label the schedule with (repeatable)")
+	addCreateOutputFlag(onetimeCreateCmd)
 
 	onetimeCmd.AddCommand(onetimeListCmd)
+	addListFlags(onetimeListCmd)
 	onetimeCmd.AddCommand(onetimeGetCmd)
+	addDetailOutputFlag(onetimeGetCmd)
 	onetimeCmd.AddCommand(onetimeDeleteCmd)
+	onetimeDeleteCmd.Flags().String("agent-id", "", "Delete all one-time schedules for this agent")
+	addBulkFlags(onetimeDeleteCmd)
+	onetimeCmd.AddCommand(onetimePruneCmd)
+	onetimePruneCmd.Flags().Bool("executed-only", false, "Only delete schedules with a recorded execution result")
+	addBulkFlags(onetimePruneCmd)
 }
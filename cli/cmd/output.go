@@ -0,0 +1,415 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputWide  = "wide"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+	outputJSONL = "jsonl"
+	outputID    = "id"
+)
+
+var outputFormats = []string{outputTable, outputWide, outputJSON, outputCSV, outputJSONL, outputID}
+
+const (
+	outputText = "text"
+	outputEnv  = "env"
+)
+
+// detailFormats are the --output formats of get commands, which show one item
+var detailFormats = []string{outputText, outputJSON, outputEnv}
+
+// createFormats are the --output formats of create commands
+var createFormats = []string{outputText, outputID}
+
+// defaultCellWidth is how many characters a table cell shows before
+// truncation unless --truncate says otherwise
+const defaultCellWidth = 50
+
+// getCellWidth returns the --truncate value; 0 means cells are never cut
+func getCellWidth() int {
+	width, _ := rootCmd.PersistentFlags().GetInt("truncate")
+	return width
+}
+
+// addOutputFlag registers the --output flag on a command
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", outputTable, "Output format: "+strings.Join(outputFormats, ", "))
+	addTemplateFlag(cmd)
+}
+
+// getOutputFormat returns the validated --output value, defaulting to
+// output_format from the config
+func getOutputFormat(cmd *cobra.Command) (string, error) {
+	return checkOutputFormat(cmd, outputFormats, configuredFormat(cmd, outputFormats))
+}
+
+// addDetailOutputFlag registers the --output flag on a get command
+func addDetailOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", outputText, "Output format: "+strings.Join(detailFormats, ", "))
+	addTemplateFlag(cmd)
+}
+
+// getDetailFormat returns the validated --output value of a get command,
+// defaulting to output_format from the config
+func getDetailFormat(cmd *cobra.Command) (string, error) {
+	return checkOutputFormat(cmd, detailFormats, configuredFormat(cmd, detailFormats))
+}
+
+// addCreateOutputFlag registers the --output flag on a create command
+func addCreateOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", outputText, "Output format: "+strings.Join(createFormats, ", ")+" (id prints only the new schedule's ID)")
+}
+
+// getCreateFormat returns the validated --output value of a create command
+func getCreateFormat(cmd *cobra.Command) (string, error) {
+	return checkOutputFormat(cmd, createFormats, "")
+}
+
+// allOutputFormats is every format some command's --output accepts, which
+// is what output_format may be set to
+func allOutputFormats() []string {
+	var all []string
+	for _, formats := range [][]string{outputFormats, detailFormats, createFormats} {
+		for _, f := range formats {
+			if !contains(all, f) {
+				all = append(all, f)
+			}
+		}
+	}
+	return all
+}
+
+// validateOutputFormatConfig checks output_format against allOutputFormats
+func validateOutputFormatConfig(cfg *config.Config) error {
+	if cfg.OutputFormat == "" || contains(allOutputFormats(), strings.ToLower(cfg.OutputFormat)) {
+		return nil
+	}
+	return fmt.Errorf("invalid output_format in config: %s (expected one of: %s)", cfg.OutputFormat, strings.Join(allOutputFormats(), ", "))
+}
+
+// configuredFormat returns output_format from the config when --output
+// wasn't given and the command supports that format, e.g. json for both
+// lists and get, but csv only for lists. Otherwise it returns "" and the
+// flag's default applies.
+func configuredFormat(cmd *cobra.Command, formats []string) string {
+	if cmd.Flags().Changed("output") {
+		return ""
+	}
+	cfg, err := configStore.Load()
+	if err != nil {
+		return ""
+	}
+	format := strings.ToLower(cfg.OutputFormat)
+	if !contains(formats, format) {
+		return ""
+	}
+	return format
+}
+
+// checkOutputFormat validates --output against formats, using fallback
+// instead of the flag's default when it is set. A --template-file selects
+// outputTemplate instead, after loading the template.
+func checkOutputFormat(cmd *cobra.Command, formats []string, fallback string) (string, error) {
+	tmpl, err := loadTemplate(cmd)
+	if err != nil {
+		return "", err
+	}
+	if tmpl != nil {
+		itemTemplate = tmpl
+		return outputTemplate, nil
+	}
+
+	format, _ := cmd.Flags().GetString("output")
+	if fallback != "" {
+		format = fallback
+	}
+	format = strings.ToLower(format)
+	for _, f := range formats {
+		if format == f {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output format: %s (expected one of: %s)", format, strings.Join(formats, ", "))
+}
+
+const (
+	tableStylePlain    = "plain"
+	tableStyleBordered = "bordered"
+	tableStyleMarkdown = "markdown"
+)
+
+var tableStyles = []string{tableStylePlain, tableStyleBordered, tableStyleMarkdown}
+
+// getTableStyle returns the --table-style value, lowercased
+func getTableStyle() string {
+	style, _ := rootCmd.PersistentFlags().GetString("table-style")
+	return strings.ToLower(style)
+}
+
+// validateTableStyle checks --table-style before any command runs, so
+// newTable never has to handle a bad value
+func validateTableStyle() error {
+	style := getTableStyle()
+	for _, s := range tableStyles {
+		if style == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid table style: %s (expected one of: %s)", style, strings.Join(tableStyles, ", "))
+}
+
+// newTable returns a table writer in the --table-style layout: borderless
+// by default, fully bordered, or a GitHub-flavored markdown table
+func newTable(header []string) *tablewriter.Table {
+	return newTableTo(os.Stdout, header)
+}
+
+// newTableTo is newTable writing to w instead of stdout
+func newTableTo(w io.Writer, header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	// tablewriter draws borders and separators by default
+	switch getTableStyle() {
+	case tableStyleBordered:
+	case tableStyleMarkdown:
+		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+		table.SetCenterSeparator("|")
+	default:
+		table.SetCenterSeparator("")
+		table.SetColumnSeparator("")
+		table.SetRowSeparator("")
+		table.SetHeaderLine(false)
+		table.SetBorder(false)
+		table.SetTablePadding("\t")
+		table.SetNoWhiteSpace(true)
+	}
+	return table
+}
+
+// column describes a list column. Wide columns are only shown with
+// --output wide.
+type column struct {
+	Header string
+	Wide   bool
+}
+
+// isTable reports whether format renders as a table
+func isTable(format string) bool {
+	return format == outputTable || format == outputWide
+}
+
+// renderList writes a list in the selected format. items is the raw data
+// used for JSON; columns and rows are used for the tables and CSV. Rows hold
+// full values for every column and long cells are only truncated in the
+// default table.
+func renderList(format string, columns []column, rows [][]string, items interface{}) error {
+	return renderListStyled(format, columns, rows, items, nil)
+}
+
+// renderListStyled is renderList with a style applied to each table cell
+// after truncation, e.g. to add color. CSV and JSON are never styled.
+func renderListStyled(format string, columns []column, rows [][]string, items interface{}, style func(cell string) string) error {
+	switch format {
+	case outputJSON:
+		return printJSON(items)
+	case outputJSONL:
+		return printJSONL(items)
+	case outputTemplate:
+		return renderTemplateList(items)
+	case outputID:
+		return printIDs(items)
+	case outputCSV:
+		header, rows := selectColumns(columns, rows, false)
+		return printCSV(header, rows)
+	default:
+		renderTable(os.Stdout, format == outputWide, columns, rows, style)
+		return nil
+	}
+}
+
+// renderTable writes rows as a table to w. Wide tables show every column
+// untruncated; otherwise wide-only columns are dropped and cells longer than
+// --truncate are cut.
+func renderTable(w io.Writer, wide bool, columns []column, rows [][]string, style func(cell string) string) {
+	if style == nil {
+		style = func(cell string) string { return cell }
+	}
+
+	width := getCellWidth()
+	header, rows := selectColumns(columns, rows, wide)
+	table := newTableTo(w, header)
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if !wide {
+				cell = truncate(cell, width)
+			}
+			cells[i] = style(cell)
+		}
+		table.Append(cells)
+	}
+	table.Render()
+}
+
+// indentWriter prefixes every line written through it, e.g. to nest a table
+// under a heading
+type indentWriter struct {
+	w      io.Writer
+	prefix string
+	// midLine is set when the last write didn't end with a newline
+	midLine bool
+}
+
+func (iw *indentWriter) Write(p []byte) (int, error) {
+	var buf []byte
+	for _, b := range p {
+		if !iw.midLine {
+			buf = append(buf, iw.prefix...)
+			iw.midLine = true
+		}
+		buf = append(buf, b)
+		if b == '\n' {
+			iw.midLine = false
+		}
+	}
+	if _, err := iw.w.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// selectColumns returns the header and rows limited to the columns shown,
+// dropping wide-only columns unless wide is set
+func selectColumns(columns []column, rows [][]string, wide bool) ([]string, [][]string) {
+	var keep []int
+	header := []string{}
+	for i, c := range columns {
+		if wide || !c.Wide {
+			keep = append(keep, i)
+			header = append(header, c.Header)
+		}
+	}
+
+	selected := make([][]string, len(rows))
+	for r, row := range rows {
+		cells := make([]string, len(keep))
+		for i, k := range keep {
+			cells[i] = row[k]
+		}
+		selected[r] = cells
+	}
+	return header, selected
+}
+
+// printCSV writes a header row and one row per item to stdout
+func printCSV(header []string, rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// printJSONL writes each element of the slice items to stdout as one line
+// of compact JSON
+func printJSONL(items interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("failed to write JSON lines: %T is not a list", items)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := enc.Encode(v.Index(i).Interface()); err != nil {
+			return fmt.Errorf("failed to write JSON lines: %w", err)
+		}
+	}
+	return nil
+}
+
+// idFields are the fields --output id prints, in order of preference:
+// schedules have an ID, results are keyed by their schedule, and stats rows
+// by their schedule or agent
+var idFields = []string{"ID", "ScheduleID", "Key"}
+
+// printIDs writes the ID of each element of the slice items to stdout, one
+// per line with nothing else, for piping into xargs
+func printIDs(items interface{}) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("failed to write IDs: %T is not a list", items)
+	}
+	for i := 0; i < v.Len(); i++ {
+		item := reflect.Indirect(v.Index(i))
+		var id reflect.Value
+		for _, name := range idFields {
+			if item.Kind() == reflect.Struct {
+				if id = item.FieldByName(name); id.IsValid() {
+					break
+				}
+			}
+		}
+		if !id.IsValid() || id.Kind() != reflect.String {
+			return fmt.Errorf("failed to write IDs: %s has no ID", item.Type())
+		}
+		fmt.Println(id.String())
+	}
+	return nil
+}
+
+// envVar is one line of --output env
+type envVar struct {
+	Name  string
+	Value string
+}
+
+// printEnv prints vars as NAME=value lines for eval in a POSIX shell
+func printEnv(vars []envVar) {
+	for _, v := range vars {
+		fmt.Printf("%s=%s\n", v.Name, shellQuote(v.Value))
+	}
+}
+
+// shellSafePattern matches values that need no quoting in a shell
+var shellSafePattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote single-quotes s unless it is made only of safe characters
+func shellQuote(s string) string {
+	if shellSafePattern.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/letta/letta-switchboard-cli/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var parseCmd = &cobra.Command{
+	Use:   "parse",
+	Short: "Run the schedule parsers on some input and print the result as JSON",
+	Long: `Run the natural-language parsers on their own and print what the input
+resolves to as JSON, for tools built on top of the CLI. Nothing is sent to the
+server and no API key is needed.
+
+  letta-switchboard parse cron "every weekday"
+  letta-switchboard parse time "tomorrow at 9am"
+
+Input that can't be parsed prints a JSON object with an "error" field and
+exits non-zero.`,
+}
+
+// parsedCron is the output of parse cron
+type parsedCron struct {
+	Input   string   `json:"input"`
+	Cron    string   `json:"cron,omitempty"`
+	Dialect string   `json:"dialect"`
+	Next    []string `json:"next,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// parsedTime is the output of parse time
+type parsedTime struct {
+	Input     string `json:"input"`
+	ExecuteAt string `json:"execute_at,omitempty"`
+	Timezone  string `json:"timezone"`
+	Error     string `json:"error,omitempty"`
+}
+
+var parseCronCmd = &cobra.Command{
+	Use:   "cron <input>",
+	Short: "Parse a cron expression or natural-language schedule",
+	Long: `Parse a cron expression or natural-language schedule the way recurring
+create does and print the cron expression with its next fire times. The
+server evaluates cron in UTC; fire times are shown in the display timezone,
+or UTC when none is set. Expressions the local evaluator can't handle, like
+Quartz W, are printed without fire times.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		count, _ := cmd.Flags().GetInt("count")
+		if count < 0 {
+			return fmt.Errorf("--count must not be negative")
+		}
+		dialectName, _ := cmd.Flags().GetString("cron-dialect")
+		dialect, err := parser.ParseCronDialect(dialectName)
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		loc, err := parseLocation(cfg)
+		if err != nil {
+			return err
+		}
+
+		out := parsedCron{Input: args[0], Dialect: string(dialect)}
+		expr, err := parser.ParseCronAs(args[0], dialect)
+		if err == nil {
+			err = parser.CheckCron(expr, dialect)
+		}
+		if err != nil {
+			out.Error = err.Error()
+			if err := printJSON(out); err != nil {
+				return err
+			}
+			return fmt.Errorf("failed to parse cron %q", args[0])
+		}
+
+		out.Cron = expr
+		if count > 0 {
+			if times, err := parser.NextFireTimes(expr, time.Now().UTC(), count); err == nil {
+				for _, t := range times {
+					out.Next = append(out.Next, t.In(loc).Format(time.RFC3339))
+				}
+			}
+		}
+		return printJSON(out)
+	},
+}
+
+var parseTimeCmd = &cobra.Command{
+	Use:   "time <input>",
+	Short: "Parse an execution time",
+	Long: `Parse an execution time the way onetime create does and print the
+timestamp that would be sent. Dates and times of day like "tomorrow at 9am"
+are read in the display timezone, or UTC when none is set. Times in the past
+are printed too; onetime create is what rejects them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		loc, err := parseLocation(cfg)
+		if err != nil {
+			return err
+		}
+
+		out := parsedTime{Input: args[0], Timezone: loc.String()}
+		executeAt, err := parseUserTime(cfg, args[0])
+		if err != nil {
+			out.Error = err.Error()
+			if err := printJSON(out); err != nil {
+				return err
+			}
+			return fmt.Errorf("failed to parse time %q", args[0])
+		}
+
+		out.ExecuteAt = executeAt
+		return printJSON(out)
+	},
+}
+
+// parseLocation returns the display timezone the parse commands use, UTC
+// when none is set
+func parseLocation(cfg *config.Config) (*time.Location, error) {
+	loc, err := displayLocation(cfg)
+	if err != nil || loc == nil {
+		return time.UTC, err
+	}
+	return loc, nil
+}
+
+func init() {
+	rootCmd.AddCommand(parseCmd)
+	parseCmd.AddCommand(parseCronCmd)
+	parseCmd.AddCommand(parseTimeCmd)
+	parseCronCmd.Flags().Int("count", 5, "Number of next fire times to include; 0 leaves them out")
+	addCronDialectFlag(parseCronCmd)
+}
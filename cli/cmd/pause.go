@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// defaultBulkConcurrency is how many schedules a bulk pause or resume
+// updates at once
+const defaultBulkConcurrency = 4
+
+var recurringPauseCmd = &cobra.Command{
+	Use:   "pause [schedule-id]",
+	Short: "Pause recurring schedules",
+	Long: `Pause a recurring schedule by ID or name, or every recurring schedule
+matching --agent-id and --tag, e.g. for a maintenance window. Paused
+schedules keep their settings but don't fire until resumed.
+
+Needs a server that supports updating schedules (PATCH /schedules/recurring/{id}).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setRecurringPaused(cmd, args, true)
+	},
+}
+
+var recurringResumeCmd = &cobra.Command{
+	Use:   "resume [schedule-id]",
+	Short: "Resume paused recurring schedules",
+	Long: `Resume a paused recurring schedule by ID or name, or every recurring
+schedule matching --agent-id and --tag.
+
+Needs a server that supports updating schedules (PATCH /schedules/recurring/{id}).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setRecurringPaused(cmd, args, false)
+	},
+}
+
+// setRecurringPaused pauses or resumes one schedule or all matching ones.
+// Schedules already in the wanted state are skipped, but only when the
+// server reports their state; otherwise the update is sent anyway.
+func setRecurringPaused(cmd *cobra.Command, args []string, pause bool) error {
+	agentID, _ := cmd.Flags().GetString("agent-id")
+	tags, err := getTags(cmd)
+	if err != nil {
+		return err
+	}
+	bulk := agentID != "" || len(tags) > 0
+	if (len(args) == 0) != bulk {
+		return fmt.Errorf("specify either a schedule ID or --agent-id/--tag")
+	}
+	workers, _ := cmd.Flags().GetInt("concurrency")
+	if workers < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	action, done, summary := "resume", "resumed", "Resumed"
+	if pause {
+		action, done, summary = "pause", "paused", "Paused"
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	apiClient := newAPIClient(cmd, cfg)
+	update := func(id string) error {
+		enabled := !pause
+		_, err := apiClient.UpdateRecurringSchedule(id, client.RecurringScheduleUpdate{Enabled: &enabled})
+		return err
+	}
+
+	if !bulk {
+		scheduleID, err := resolveRecurringID(apiClient, args[0])
+		if err != nil {
+			return err
+		}
+		if err := update(scheduleID); err != nil {
+			return fmt.Errorf("failed to %s schedule: %w", action, err)
+		}
+		invalidateCache(cfg, recurringCacheKey)
+
+		color.Green("✓ Schedule %s %s", scheduleID, done)
+		return nil
+	}
+
+	schedules, err := apiClient.ListRecurringSchedules()
+	if err != nil {
+		return fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	var ids []string
+	unchanged := 0
+	for _, s := range schedules {
+		if (agentID != "" && s.AgentID != agentID) || !hasTags(s.Tags, tags) {
+			continue
+		}
+		if paused, known := pauseState(s); known && paused == pause {
+			unchanged++
+			continue
+		}
+		ids = append(ids, s.ID)
+	}
+	if len(ids) == 0 {
+		if unchanged > 0 {
+			fmt.Printf("All %d matching recurring schedules are already %s\n", unchanged, done)
+			return nil
+		}
+		fmt.Printf("No recurring schedules match %s\n", describeSelector(agentID, tags))
+		return nil
+	}
+
+	ok, err := confirmBulk(cmd, action, ids)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Aborted")
+		return nil
+	}
+
+	changed, err := runBulkConcurrent(ids, workers, failFast(cmd), update)
+	invalidateCache(cfg, recurringCacheKey)
+	if err != nil {
+		return bulkFailure(done, changed, len(ids), failFast(cmd), err)
+	}
+
+	color.Green("✓ %s %d schedules", summary, changed)
+	if unchanged > 0 {
+		fmt.Printf("%d more were already %s\n", unchanged, done)
+	}
+	return nil
+}
+
+// pauseState reports whether a schedule is paused, and whether the server
+// said either way with enabled or paused_at. A schedule without paused_at
+// may be running or on a server that doesn't track pausing, so only an
+// enabled field reports it running.
+func pauseState(s client.RecurringSchedule) (paused, known bool) {
+	if s.Enabled != nil {
+		return !*s.Enabled, true
+	}
+	if s.PausedAt != nil {
+		return true, true
+	}
+	return false, false
+}
+
+// describeSelector names the --agent-id and --tag filters of a bulk command
+func describeSelector(agentID string, tags []string) string {
+	var parts []string
+	if agentID != "" {
+		parts = append(parts, "agent "+agentID)
+	}
+	if len(tags) > 0 {
+		parts = append(parts, "tags "+strings.Join(tags, ", "))
+	}
+	return strings.Join(parts, " and ")
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{recurringPauseCmd, recurringResumeCmd} {
+		recurringCmd.AddCommand(cmd)
+		cmd.Flags().String("agent-id", "", "Act on all recurring schedules for this agent")
+		addTagFlag(cmd, "Act on all recurring schedules with this tag (repeatable; all must match)")
+		cmd.Flags().Int("concurrency", defaultBulkConcurrency, "How many schedules to update at once")
+		addBulkFlags(cmd)
+	}
+}
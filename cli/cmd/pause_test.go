@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+)
+
+func TestPauseState(t *testing.T) {
+	yes, no := true, false
+	pausedAt := &client.FlexTime{}
+
+	tests := []struct {
+		name   string
+		s      client.RecurringSchedule
+		paused bool
+		known  bool
+	}{
+		{"no state reported", client.RecurringSchedule{}, false, false},
+		{"paused_at set", client.RecurringSchedule{PausedAt: pausedAt}, true, true},
+		{"enabled", client.RecurringSchedule{Enabled: &yes}, false, true},
+		{"disabled", client.RecurringSchedule{Enabled: &no}, true, true},
+		{"enabled wins over paused_at", client.RecurringSchedule{Enabled: &yes, PausedAt: pausedAt}, false, true},
+	}
+
+	for _, tt := range tests {
+		paused, known := pauseState(tt.s)
+		if paused != tt.paused || known != tt.known {
+			t.Errorf("%s: pauseState = %v, %v; want %v, %v", tt.name, paused, known, tt.paused, tt.known)
+		}
+	}
+}
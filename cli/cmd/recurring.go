@@ -1,14 +1,19 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"math/rand"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/letta/letta-switchboard-cli/internal/client"
 	"github.com/letta/letta-switchboard-cli/internal/config"
 	"github.com/letta/letta-switchboard-cli/internal/parser"
-	"github.com/olekukonko/tablewriter"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -19,106 +24,404 @@ var recurringCmd = &cobra.Command{
 }
 
 var recurringCreateCmd = &cobra.Command{
-	Use:   "create",
+	Use:   "create [agent-id] [cron] [message]",
 	Short: "Create a new recurring schedule",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		agentID, _ := cmd.Flags().GetString("agent-id")
-		message, _ := cmd.Flags().GetString("message")
-		role, _ := cmd.Flags().GetString("role")
-		cronString, _ := cmd.Flags().GetString("cron")
+	Long: `Create a new recurring schedule from flags, or from the shorthand
+positional arguments:
 
-		if agentID == "" || message == "" || cronString == "" {
-			return fmt.Errorf("agent-id, message, and cron are required")
-		}
+  letta-switchboard recurring create agent-xxx "daily at 9am" "Daily summary please"
 
-		// Parse natural language to cron expression
-		parsedCron, err := parser.ParseCron(cronString)
+The agent ID can be left out when default_agent_id is set. --agent-id, --cron
+and --message win over the positional values when both are given.`,
+	Args: cobra.MaximumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runNow, _ := cmd.Flags().GetBool("run-now")
+		jitter, _ := cmd.Flags().GetDuration("jitter")
+		format, err := getCreateFormat(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to parse cron: %w", err)
+			return err
+		}
+		if format == outputID && runNow {
+			return fmt.Errorf("--run-now can't be combined with --output id")
 		}
 
-		cfg, err := config.Load()
+		create, cfg, err := recurringScheduleFromFlags(cmd, args)
 		if err != nil {
 			return err
 		}
-		if err := cfg.Validate(); err != nil {
-			return err
+
+		var offset int
+		if jitter != 0 {
+			if jitter < time.Minute {
+				return fmt.Errorf("--jitter must be at least 1m, got %s", jitter)
+			}
+			create.CronString, offset, err = parser.JitterCron(create.CronString, int(jitter/time.Minute), rand.Intn)
+			if err != nil {
+				return fmt.Errorf("can't apply --jitter: %w", err)
+			}
 		}
 
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
-		schedule, err := apiClient.CreateRecurringSchedule(client.RecurringScheduleCreate{
-			AgentID:    agentID,
-			Message:    message,
-			Role:       role,
-			CronString: parsedCron,
-		})
+		if confirm, _ := cmd.Flags().GetBool("confirm-cron"); confirm || cfg.ConfirmCron {
+			ok, err := confirmCron(cmd, cfg, create)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Cancelled")
+				return nil
+			}
+		}
+
+		if raw, _ := cmd.Flags().GetBool("raw-cron"); !raw {
+			if create.CronString, err = serverCron(cfg, create.CronString); err != nil {
+				return err
+			}
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		schedule, err := apiClient.CreateRecurringSchedule(*create)
 		if err != nil {
 			return fmt.Errorf("failed to create schedule: %w", err)
 		}
+		invalidateCache(cfg, recurringCacheKey)
 
+		if format == outputID {
+			fmt.Println(schedule.ID)
+			return nil
+		}
 		color.Green("✓ Recurring schedule created successfully")
 		fmt.Printf("\nSchedule ID: %s\n", schedule.ID)
+		if schedule.Name != "" {
+			fmt.Printf("Name:        %s\n", schedule.Name)
+		}
 		fmt.Printf("Agent ID:    %s\n", schedule.AgentID)
 		fmt.Printf("Cron:        %s\n", schedule.CronString)
+		if jitter != 0 {
+			fmt.Printf("Jitter:      +%dm (up to %s)\n", offset, jitter)
+		}
+		printStepMinutes(schedule.CronString)
+		if schedule.StartAt != "" {
+			fmt.Printf("Start:       %s\n", schedule.StartAt)
+		}
+		if schedule.EndAt != "" {
+			fmt.Printf("End:         %s\n", schedule.EndAt)
+		}
+		if len(schedule.Tags) > 0 {
+			fmt.Printf("Tags:        %s\n", strings.Join(schedule.Tags, ", "))
+		}
 		fmt.Printf("Message:     %s\n", schedule.Message)
 
+		if runNow {
+			return runRecurringNow(cfg, apiClient, schedule)
+		}
 		return nil
 	},
 }
 
-var recurringListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all recurring schedules",
+var recurringEnsureCmd = &cobra.Command{
+	Use:   "ensure [agent-id] [cron] [message]",
+	Short: "Create a recurring schedule unless an identical one exists",
+	Long: `Create a recurring schedule only if no schedule with the same agent, cron
+and message exists, printing "created <id>" or "unchanged <id>". Running it
+again with the same flags changes nothing, so provisioning scripts can call it
+on every run. It takes the same positional shorthand as create.`,
+	Args: cobra.MaximumNArgs(3),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		create, cfg, err := recurringScheduleFromFlags(cmd, args)
 		if err != nil {
 			return err
 		}
-		if err := cfg.Validate(); err != nil {
-			return err
+		if raw, _ := cmd.Flags().GetBool("raw-cron"); !raw {
+			if create.CronString, err = serverCron(cfg, create.CronString); err != nil {
+				return err
+			}
 		}
 
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
+		apiClient := newAPIClient(cmd, cfg)
 		schedules, err := apiClient.ListRecurringSchedules()
 		if err != nil {
 			return fmt.Errorf("failed to list schedules: %w", err)
 		}
+		for _, s := range schedules {
+			if s.AgentID == create.AgentID && s.Message == create.Message && sameCron(s.CronString, create.CronString) {
+				fmt.Printf("unchanged %s\n", s.ID)
+				return nil
+			}
+		}
 
-		if len(schedules) == 0 {
-			fmt.Println("No recurring schedules found")
-			return nil
+		schedule, err := apiClient.CreateRecurringSchedule(*create)
+		if err != nil {
+			return fmt.Errorf("failed to create schedule: %w", err)
 		}
+		invalidateCache(cfg, recurringCacheKey)
 
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Schedule ID", "Agent ID", "Cron", "Message", "Last Run"})
-		table.SetAutoWrapText(false)
-		table.SetAutoFormatHeaders(true)
-		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-		table.SetAlignment(tablewriter.ALIGN_LEFT)
-		table.SetCenterSeparator("")
-		table.SetColumnSeparator("")
-		table.SetRowSeparator("")
-		table.SetHeaderLine(false)
-		table.SetBorder(false)
-		table.SetTablePadding("\t")
-		table.SetNoWhiteSpace(true)
+		fmt.Printf("created %s\n", schedule.ID)
+		return nil
+	},
+}
 
-		for _, s := range schedules {
-			lastRun := "never"
-			if s.LastRun != nil && *s.LastRun != "" {
-				lastRun = *s.LastRun
-			}
-			table.Append([]string{
-				s.ID,
-				s.AgentID,
-				s.CronString,
-				truncate(s.Message, 50),
-				lastRun,
-			})
+// addRecurringScheduleFlags registers the flags describing a new recurring
+// schedule, shared by create and ensure
+func addRecurringScheduleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("agent-id", "", "Agent ID (default default_agent_id from the config)")
+	cmd.Flags().StringArray("message", nil, "Message to send (required)\n  Repeating it to rotate through several messages needs server support and is rejected for now")
+	addStrictMessageFlag(cmd)
+	addNameFlags(cmd)
+	cmd.Flags().String("role", "", "Message role: user, system, assistant, or a role_aliases name (default: recurring_default_role from config, or user)")
+	cmd.Flags().String("cron", "", "Schedule pattern (required)\n  Examples: 'every 5 minutes', 'daily at 9am', 'every monday at 3pm', 'every tue through thu at 10am', '*/5 * * * *'")
+	cmd.Flags().String("start", "", "When the schedule becomes active (optional)\n  Examples: 'tomorrow at 9am', '2025-12-01T00:00:00Z'")
+	cmd.Flags().String("end", "", "When the schedule stops firing (optional)\n  Examples: 'in 30 days', '2025-12-31T23:59:00Z'")
+	cmd.Flags().Bool("raw-cron", false, "Send --cron verbatim as a five-field cron expression, skipping natural-language parsing")
+	addCronDialectFlag(cmd)
+	addTagFlag(cmd, "Tag to label the schedule with (repeatable)")
+	cmd.Flags().Bool("force", false, "Create the schedule even if it fires more often than min_cron_interval")
+}
+
+// recurringScheduleFromFlags validates the flags from
+// addRecurringScheduleFlags, filled in from any shorthand args, and builds
+// the schedule to create, loading the config along the way
+func recurringScheduleFromFlags(cmd *cobra.Command, args []string) (*client.RecurringScheduleCreate, *config.Config, error) {
+	if err := applyShorthandArgs(cmd, args, "cron"); err != nil {
+		return nil, nil, err
+	}
+	agentID, _ := cmd.Flags().GetString("agent-id")
+	messages, _ := cmd.Flags().GetStringArray("message")
+	role, _ := cmd.Flags().GetString("role")
+	cronString, _ := cmd.Flags().GetString("cron")
+	start, _ := cmd.Flags().GetString("start")
+	end, _ := cmd.Flags().GetString("end")
+	rawCron, _ := cmd.Flags().GetBool("raw-cron")
+	dialectName, _ := cmd.Flags().GetString("cron-dialect")
+	name, description := getNameFlags(cmd)
+
+	if len(messages) == 0 || messages[0] == "" || cronString == "" {
+		return nil, nil, fmt.Errorf("message and cron are required")
+	}
+	// The API stores one message per schedule; the create type has
+	// Messages for when rotation is supported server-side
+	if len(messages) > 1 {
+		return nil, nil, fmt.Errorf("got %d --message values, but the API sends one message per schedule; rotating messages needs server support", len(messages))
+	}
+
+	strictMessage, _ := cmd.Flags().GetBool("strict-message")
+	message, err := cleanMessage(messages[0], strictMessage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tags, err := getTags(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	dialect, err := parser.ParseCronDialect(dialectName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Parse natural language to cron expression, unless the user
+	// wants the expression sent exactly as written
+	var parsedCron string
+	if rawCron {
+		parsedCron = strings.TrimSpace(cronString)
+		if err := parser.ValidateRawCron(parsedCron, dialect); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		parsedCron, err = parser.ParseCronAs(cronString, dialect)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse cron: %w", err)
+		}
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	startAt, endAt, err := parseActiveWindow(cfg, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	agentID, err = resolveAgentID(cfg, agentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if force, _ := cmd.Flags().GetBool("force"); !force {
+		if err := checkCronFrequency(cfg, parsedCron); err != nil {
+			return nil, nil, err
 		}
+	}
+	if role == "" {
+		role = cfg.RecurringDefaultRole
+	}
+	role, err = resolveRole(cfg, role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &client.RecurringScheduleCreate{
+		AgentID:     agentID,
+		Name:        name,
+		Description: description,
+		Message:     message,
+		Role:        role,
+		CronString:  parsedCron,
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Tags:        tags,
+	}, cfg, nil
+}
 
-		table.Render()
+// addCronDialectFlag registers --cron-dialect on a command
+func addCronDialectFlag(cmd *cobra.Command) {
+	cmd.Flags().String("cron-dialect", string(parser.CronDialectStandard), "Cron syntax to accept: standard, or quartz to also allow ? L W and # in the day fields")
+}
+
+// checkCronFrequency rejects cron expressions that fire more often than
+// min_cron_interval, such as an accidental "* * * * *". Expressions the local
+// evaluator can't handle are let through for the server to judge.
+func checkCronFrequency(cfg *config.Config, expr string) error {
+	if cfg.MinCronInterval <= 0 {
+		return nil
+	}
+	interval, err := parser.MinInterval(expr, time.Now().UTC())
+	if err != nil || interval == 0 || interval >= cfg.MinCronInterval {
 		return nil
+	}
+	return fmt.Errorf("cron %q fires as often as every %s, more often than min_cron_interval (%s); pass --force if that's intended", expr, interval, cfg.MinCronInterval)
+}
+
+// serverCron rewrites the day-of-week field of a parsed cron expression in
+// the numbering the server expects, set by cron_weekdays. Local checks and
+// previews read the expression before this, as standard cron; --raw-cron
+// expressions are sent untouched.
+func serverCron(cfg *config.Config, expr string) (string, error) {
+	numbering, err := parser.ParseWeekdayNumbering(cfg.CronWeekdays)
+	if err != nil {
+		return "", err
+	}
+	return parser.NormalizeWeekdays(expr, numbering)
+}
+
+// sameCron reports whether two cron expressions are the same apart from
+// spacing and letter case
+func sameCron(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
+
+// runRecurringNow fires a recurring schedule's message once immediately. The
+// API has no endpoint for triggering a recurring schedule, so this creates a
+// one-time schedule for now with the same agent, message, role and tags; its
+// run ID shows up under results once the scheduler has executed it.
+func runRecurringNow(cfg *config.Config, apiClient *client.Client, schedule *client.RecurringSchedule) error {
+	executeAt, err := parser.ParseTime("now")
+	if err != nil {
+		return err
+	}
+	run, err := apiClient.CreateOneTimeSchedule(client.OneTimeScheduleCreate{
+		AgentID:   schedule.AgentID,
+		Message:   schedule.Message,
+		Role:      schedule.Role,
+		ExecuteAt: executeAt,
+		Tags:      schedule.Tags,
+	})
+	if err != nil {
+		return fmt.Errorf("schedule %s was created, but triggering the immediate run failed: %w", schedule.ID, err)
+	}
+	invalidateCache(cfg, onetimeCacheKey)
+
+	fmt.Printf("\nImmediate run scheduled as one-time schedule %s\n", run.ID)
+	fmt.Printf("Its run ID shows up once it executes: letta-switchboard results get %s\n", run.ID)
+	return nil
+}
+
+var recurringListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all recurring schedules",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := getListOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		loc, err := displayLocation(cfg)
+		if err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		apiClient.Scope = opts.scope()
+		return watchList(cmd, func() error {
+			schedules, err := apiClient.ListRecurringSchedules()
+			if err != nil {
+				return fmt.Errorf("failed to list schedules: %w", err)
+			}
+
+			// A scoped list is only part of what get commands look up
+			if !opts.scoped() {
+				storeCache(cmd, cfg, recurringCacheKey, schedules)
+			}
+			opts.warnUnscoped(schedules, nil)
+
+			schedules = filterRecurring(schedules, opts)
+			if len(schedules) == 0 && isTable(opts.Output) {
+				fmt.Println("No recurring schedules found")
+				return nil
+			}
+
+			now := time.Now()
+			rows := [][]string{}
+			for _, s := range schedules {
+				lastRun := "never"
+				if s.LastRun != nil && *s.LastRun != "" {
+					lastRun = formatTime(loc, *s.LastRun)
+				}
+				rows = append(rows, []string{
+					s.ID,
+					orDash(s.Name),
+					s.AgentID,
+					s.CronString,
+					s.Message,
+					orDash(formatTime(loc, s.StartAt)),
+					orDash(formatTime(loc, s.EndAt)),
+					orDash(strings.Join(s.Tags, ",")),
+					lastRun,
+					formatAge(s.CreatedAt, now),
+					s.Role,
+					orDash(s.Owner),
+					orDash(s.Description),
+					formatFlexTime(loc, s.CreatedAt),
+				})
+			}
+
+			columns := []column{
+				{Header: "Schedule ID"},
+				{Header: "Name"},
+				{Header: "Agent ID"},
+				{Header: "Cron"},
+				{Header: "Message"},
+				{Header: "Start"},
+				{Header: "End"},
+				{Header: "Tags"},
+				{Header: "Last Run"},
+				{Header: "Age"},
+				{Header: "Role", Wide: true},
+				{Header: "Owner", Wide: true},
+				{Header: "Description", Wide: true},
+				{Header: "Created At", Wide: true},
+			}
+			return renderList(opts.Output, columns, rows, schedules)
+		})
 	},
 }
 
@@ -128,45 +431,196 @@ var recurringGetCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		scheduleID := args[0]
+		format, err := getDetailFormat(cmd)
+		if err != nil {
+			return err
+		}
 
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
-
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
-		schedule, err := apiClient.GetRecurringSchedule(scheduleID)
+		loc, err := displayLocation(cfg)
 		if err != nil {
-			return fmt.Errorf("failed to get schedule: %w", err)
+			return err
+		}
+
+		schedule := cachedRecurringSchedule(cmd, cfg, scheduleID)
+		if schedule == nil {
+			apiClient := newAPIClient(cmd, cfg)
+			scheduleID, err = resolveRecurringID(apiClient, scheduleID)
+			if err != nil {
+				return err
+			}
+			schedule, err = apiClient.GetRecurringSchedule(scheduleID)
+			if err != nil {
+				return fmt.Errorf("failed to get schedule: %w", err)
+			}
+		}
+
+		switch format {
+		case outputJSON:
+			return printJSON(schedule)
+		case outputTemplate:
+			return renderTemplate(schedule)
+		case outputEnv:
+			printEnv(recurringScheduleEnv(schedule))
+			return nil
 		}
 
 		fmt.Printf("Schedule ID:  %s\n", schedule.ID)
+		printNameLines(schedule.Name, schedule.Description)
 		fmt.Printf("Agent ID:     %s\n", schedule.AgentID)
 		fmt.Printf("Cron:         %s\n", schedule.CronString)
-		fmt.Printf("Message:      %s\n", schedule.Message)
+		if len(schedule.Messages) > 1 {
+			fmt.Printf("Messages:     %d, rotation %s\n", len(schedule.Messages), orDash(schedule.MessageRotation))
+			for i, m := range schedule.Messages {
+				fmt.Printf("  %d. %s\n", i+1, m)
+			}
+		} else {
+			fmt.Printf("Message:      %s\n", schedule.Message)
+		}
 		fmt.Printf("Role:         %s\n", schedule.Role)
+		printOwnerLines(schedule.Owner, schedule.Team)
+		if len(schedule.Tags) > 0 {
+			fmt.Printf("Tags:         %s\n", strings.Join(schedule.Tags, ", "))
+		}
+		if schedule.StartAt != "" {
+			fmt.Printf("Start:        %s\n", formatTime(loc, schedule.StartAt))
+		}
+		if schedule.EndAt != "" {
+			fmt.Printf("End:          %s\n", formatTime(loc, schedule.EndAt))
+		}
 		if schedule.LastRun != nil {
-			fmt.Printf("Last Run:     %s\n", *schedule.LastRun)
+			fmt.Printf("Last Run:     %s\n", formatTime(loc, *schedule.LastRun))
 		} else {
 			fmt.Printf("Last Run:     never\n")
 		}
-		fmt.Printf("Created At:   %s\n", schedule.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("Created At:   %s\n", formatFlexTime(loc, schedule.CreatedAt))
+		if schedule.UpdatedAt != nil {
+			fmt.Printf("Updated At:   %s\n", formatFlexTime(loc, *schedule.UpdatedAt))
+		}
+		if schedule.PausedAt != nil {
+			fmt.Printf("Paused At:    %s\n", formatFlexTime(loc, *schedule.PausedAt))
+		}
+
+		return nil
+	},
+}
+
+// recurringScheduleEnv lists a schedule's fields for --output env. Times are
+// as the API returns them; unset fields are empty.
+func recurringScheduleEnv(s *client.RecurringSchedule) []envVar {
+	var lastRun, pausedAt string
+	if s.LastRun != nil {
+		lastRun = *s.LastRun
+	}
+	if s.PausedAt != nil {
+		pausedAt = s.PausedAt.Format(time.RFC3339)
+	}
+	return []envVar{
+		{"SCHEDULE_ID", s.ID},
+		{"NAME", s.Name},
+		{"DESCRIPTION", s.Description},
+		{"AGENT_ID", s.AgentID},
+		{"CRON", s.CronString},
+		{"MESSAGE", s.Message},
+		{"ROLE", s.Role},
+		{"TAGS", strings.Join(s.Tags, ",")},
+		{"START_AT", s.StartAt},
+		{"END_AT", s.EndAt},
+		{"LAST_RUN", lastRun},
+		{"CREATED_AT", s.CreatedAt.Format(time.RFC3339)},
+		{"PAUSED_AT", pausedAt},
+	}
+}
+
+var recurringNextCmd = &cobra.Command{
+	Use:   "next [schedule-id]",
+	Short: "Show the next fire times of a recurring schedule",
+	Long: `Show when a recurring schedule will fire next, computed locally from its
+cron expression. The server evaluates cron in UTC; times are shown in the
+display timezone, or local time when none is set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		count, _ := cmd.Flags().GetInt("count")
+		if count <= 0 {
+			return fmt.Errorf("--count must be a positive number")
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		loc, err := displayLocation(cfg)
+		if err != nil {
+			return err
+		}
+		if loc == nil {
+			loc = time.Local
+		}
+
+		schedule := cachedRecurringSchedule(cmd, cfg, args[0])
+		if schedule == nil {
+			apiClient := newAPIClient(cmd, cfg)
+			scheduleID, err := resolveRecurringID(apiClient, args[0])
+			if err != nil {
+				return err
+			}
+			schedule, err = apiClient.GetRecurringSchedule(scheduleID)
+			if err != nil {
+				return fmt.Errorf("failed to get schedule: %w", err)
+			}
+		}
+
+		// Nothing fires before the active window opens
+		from := time.Now().UTC()
+		if start, ok := parseAPITime(schedule.StartAt); ok && start.After(from) {
+			from = start.Add(-time.Minute)
+		}
+		times, err := parser.NextFireTimes(schedule.CronString, from, count)
+		if err != nil {
+			return fmt.Errorf("failed to compute fire times: %w", err)
+		}
 
+		fmt.Printf("Next fire times for %s (%s):\n", schedule.ID, schedule.CronString)
+		end, hasEnd := parseAPITime(schedule.EndAt)
+		for _, t := range times {
+			if hasEnd && t.After(end) {
+				fmt.Printf("  (schedule ends %s)\n", end.In(loc).Format(nextFireLayout))
+				break
+			}
+			fmt.Printf("  %s\n", t.In(loc).Format(nextFireLayout))
+		}
+		if paused, _ := pauseState(*schedule); paused {
+			color.Yellow("Schedule is paused; it won't fire until resumed")
+		}
 		return nil
 	},
 }
 
+// nextFireLayout includes the weekday, which matters most when reading a
+// schedule's upcoming runs
+const nextFireLayout = "Mon 2006-01-02 15:04 -07:00"
+
 var recurringDeleteCmd = &cobra.Command{
 	Use:   "delete [schedule-id]",
 	Short: "Delete a recurring schedule",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Delete a recurring schedule by ID, or all recurring schedules for an agent with --agent-id",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		scheduleID := args[0]
+		agentID, _ := cmd.Flags().GetString("agent-id")
+		if (len(args) == 0) == (agentID == "") {
+			return fmt.Errorf("specify either a schedule ID or --agent-id")
+		}
 
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
@@ -174,12 +628,54 @@ var recurringDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
-		if err := apiClient.DeleteRecurringSchedule(scheduleID); err != nil {
-			return fmt.Errorf("failed to delete schedule: %w", err)
+		apiClient := newAPIClient(cmd, cfg)
+
+		if agentID == "" {
+			scheduleID, err := resolveRecurringID(apiClient, args[0])
+			if err != nil {
+				return err
+			}
+			if err := apiClient.DeleteRecurringSchedule(scheduleID); err != nil {
+				return fmt.Errorf("failed to delete schedule: %w", err)
+			}
+			invalidateCache(cfg, recurringCacheKey)
+
+			color.Green("✓ Schedule %s deleted successfully", scheduleID)
+			return nil
+		}
+
+		schedules, err := apiClient.ListRecurringSchedules()
+		if err != nil {
+			return fmt.Errorf("failed to list schedules: %w", err)
+		}
+
+		var ids []string
+		for _, s := range schedules {
+			if s.AgentID == agentID {
+				ids = append(ids, s.ID)
+			}
+		}
+		if len(ids) == 0 {
+			fmt.Printf("No recurring schedules found for agent %s\n", agentID)
+			return nil
+		}
+
+		ok, err := confirmBulk(cmd, "delete", ids)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted")
+			return nil
+		}
+
+		deleted, err := runBulk(ids, failFast(cmd), apiClient.DeleteRecurringSchedule)
+		invalidateCache(cfg, recurringCacheKey)
+		if err != nil {
+			return bulkFailure("deleted", deleted, len(ids), failFast(cmd), err)
 		}
 
-		color.Green("✓ Schedule deleted successfully")
+		color.Green("✓ Deleted %d schedules", deleted)
 		return nil
 	},
 }
@@ -188,19 +684,143 @@ func init() {
 	rootCmd.AddCommand(recurringCmd)
 
 	recurringCmd.AddCommand(recurringCreateCmd)
-	recurringCreateCmd.Flags().String("agent-id", "", "Agent ID (required)")
-	recurringCreateCmd.Flags().String("message", "", "Message to send (required)")
-	recurringCreateCmd.Flags().String("role", "user", "Message role (default: user)")
-	recurringCreateCmd.Flags().String("cron", "", "Schedule pattern (required)\n  Examples: 'every 5 minutes', 'daily at 9am', 'every monday at 3pm', '*/5 * * * *'")
+	addRecurringScheduleFlags(recurringCreateCmd)
+	recurringCreateCmd.Flags().Bool("run-now", false, "Also send the message once right away; with no run-now endpoint in the API, this creates a one-time schedule")
+	addCreateOutputFlag(recurringCreateCmd)
+	recurringCreateCmd.Flags().Bool("confirm-cron", false, "Show the resolved cron and its next runs and ask before creating (when run interactively)")
+	recurringCreateCmd.Flags().BoolP("yes", "y", false, "Skip the --confirm-cron prompt")
+	recurringCreateCmd.Flags().Duration("jitter", 0, "Delay the cron's minute by a random amount up to this long, e.g. 10m, to spread out\n  schedules that would otherwise fire together")
+
+	recurringCmd.AddCommand(recurringEnsureCmd)
+	addRecurringScheduleFlags(recurringEnsureCmd)
 
 	recurringCmd.AddCommand(recurringListCmd)
+	addListFlags(recurringListCmd)
 	recurringCmd.AddCommand(recurringGetCmd)
+	addDetailOutputFlag(recurringGetCmd)
+	recurringCmd.AddCommand(recurringNextCmd)
+	recurringNextCmd.Flags().Int("count", 10, "Number of fire times to show")
 	recurringCmd.AddCommand(recurringDeleteCmd)
+	recurringDeleteCmd.Flags().String("agent-id", "", "Delete all recurring schedules for this agent")
+	addBulkFlags(recurringDeleteCmd)
 }
 
+// truncate shortens s to maxLen characters, ending in "..." when there's
+// room for it; maxLen 0 means no limit
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	runes := []rune(s)
+	if maxLen <= 0 || len(runes) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+	if maxLen <= 3 {
+		return string(runes[:maxLen])
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
+
+// confirmCronPreview is how many upcoming runs --confirm-cron shows
+const confirmCronPreview = 5
+
+// confirmCron shows what a natural-language cron resolved to and when it
+// will next fire, then asks whether to create the schedule. It doesn't
+// prompt with --yes or when stdin or stdout isn't a terminal, so scripts are
+// never blocked.
+func confirmCron(cmd *cobra.Command, cfg *config.Config, create *client.RecurringScheduleCreate) (bool, error) {
+	yes, _ := cmd.Flags().GetBool("yes")
+	if yes || !isatty.IsTerminal(os.Stdin.Fd()) || !isatty.IsTerminal(os.Stdout.Fd()) {
+		return true, nil
+	}
+
+	loc, err := displayLocation(cfg)
+	if err != nil {
+		return false, err
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	fmt.Printf("Cron: %s\n", create.CronString)
+	from := time.Now().UTC()
+	if start, ok := parseAPITime(create.StartAt); ok && start.After(from) {
+		from = start.Add(-time.Minute)
+	}
+	// Expressions the local evaluator can't handle, like Quartz W, just
+	// show the cron
+	if times, err := parser.NextFireTimes(create.CronString, from, confirmCronPreview); err == nil {
+		fmt.Println("Next runs:")
+		for _, t := range times {
+			fmt.Printf("  %s\n", t.In(loc).Format(nextFireLayout))
+		}
+	}
+
+	fmt.Print("Create this schedule? [Y/n]: ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, nil
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "" || answer == "y" || answer == "yes", nil
+}
+
+// printStepMinutes explains when a */N minute step actually fires, since
+// steps that don't divide 60 evenly leave a shorter gap at the top of the hour
+func printStepMinutes(cronString string) {
+	minutes := parser.StepMinutes(cronString)
+	if minutes == nil {
+		return
+	}
+
+	fires := make([]string, len(minutes))
+	for i, m := range minutes {
+		fires[i] = strconv.Itoa(m)
+	}
+	fmt.Printf("Fires at:    minutes %s of every hour\n", strings.Join(fires, ", "))
+
+	step, _ := strconv.Atoi(strings.TrimPrefix(strings.Fields(cronString)[0], "*/"))
+	if 60%step != 0 {
+		last := minutes[len(minutes)-1]
+		color.Yellow("⚠ */%d doesn't divide the hour evenly: after :%02d the next run is at :00, %d minutes later", step, last, 60-last)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// parseActiveWindow resolves the optional --start/--end values to ISO 8601
+// and checks that they describe a usable date range
+func parseActiveWindow(cfg *config.Config, start, end string) (string, string, error) {
+	var startAt, endAt string
+	var startTime, endTime time.Time
+
+	if start != "" {
+		parsed, err := parseUserTime(cfg, start)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to parse start: %w", err)
+		}
+		startAt = parsed
+		startTime, _ = time.Parse(time.RFC3339, parsed)
+	}
+
+	if end != "" {
+		parsed, err := parseUserTime(cfg, end)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to parse end: %w", err)
+		}
+		endAt = parsed
+		endTime, _ = time.Parse(time.RFC3339, parsed)
+
+		if !endTime.After(time.Now()) {
+			return "", "", fmt.Errorf("end must be in the future")
+		}
+	}
+
+	if startAt != "" && endAt != "" && !endTime.After(startTime) {
+		return "", "", fmt.Errorf("end must be after start")
+	}
+
+	return startAt, endAt, nil
 }
@@ -1,12 +1,20 @@
 package cmd
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/letta/letta-switchboard-cli/internal/client"
 	"github.com/letta/letta-switchboard-cli/internal/config"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
@@ -20,51 +28,78 @@ var resultsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all execution results",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		output, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
+		loc, err := displayLocation(cfg)
+		if err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+
+		// JSON lines are written as results arrive rather than after the
+		// whole list has been read
+		if output == outputJSONL {
+			enc := json.NewEncoder(os.Stdout)
+			roles := newResultRoles(apiClient)
+			err := apiClient.EachResult(func(r client.ExecutionResult) error {
+				roles.fill(&r)
+				return enc.Encode(r)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list results: %w", err)
+			}
+			return nil
+		}
 
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
 		results, err := apiClient.ListResults()
 		if err != nil {
 			return fmt.Errorf("failed to list results: %w", err)
 		}
 
-		if len(results) == 0 {
+		if len(results) == 0 && isTable(output) {
 			fmt.Println("No execution results found")
 			return nil
 		}
+		fillResultRoles(apiClient, results)
 
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Schedule ID", "Type", "Agent ID", "Run ID", "Executed At"})
-		table.SetAutoWrapText(false)
-		table.SetAutoFormatHeaders(true)
-		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-		table.SetAlignment(tablewriter.ALIGN_LEFT)
-		table.SetCenterSeparator("")
-		table.SetColumnSeparator("")
-		table.SetRowSeparator("")
-		table.SetHeaderLine(false)
-		table.SetBorder(false)
-		table.SetTablePadding("\t")
-		table.SetNoWhiteSpace(true)
-
+		rows := [][]string{}
 		for _, r := range results {
-			table.Append([]string{
+			rows = append(rows, []string{
 				r.ScheduleID,
 				r.ScheduleType,
+				orDash(r.Status),
 				r.AgentID,
+				orDash(r.Role),
 				r.RunID,
-				r.ExecutedAt,
+				formatTime(loc, r.ExecutedAt),
+				r.Message,
+				orDash(r.Error),
 			})
 		}
 
-		table.Render()
-		return nil
+		columns := []column{
+			{Header: "Schedule ID"},
+			{Header: "Type"},
+			{Header: "Status"},
+			{Header: "Agent ID"},
+			{Header: "Role"},
+			{Header: "Run ID"},
+			{Header: "Executed At"},
+			{Header: "Message", Wide: true},
+			{Header: "Error", Wide: true},
+		}
+		return renderList(output, columns, rows, results)
 	},
 }
 
@@ -74,34 +109,454 @@ var resultsGetCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		scheduleID := args[0]
+		format, err := getDetailFormat(cmd)
+		if err != nil {
+			return err
+		}
 
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
+		loc, err := displayLocation(cfg)
+		if err != nil {
+			return err
+		}
 
-		apiClient := client.NewClient(cfg.BaseURL, cfg.APIKey)
+		apiClient := newAPIClient(cmd, cfg)
 		result, err := apiClient.GetResult(scheduleID)
 		if err != nil {
 			return fmt.Errorf("failed to get result: %w", err)
 		}
+		filled := []client.ExecutionResult{*result}
+		fillResultRoles(apiClient, filled)
+		result = &filled[0]
+
+		switch format {
+		case outputJSON:
+			return printJSON(result)
+		case outputTemplate:
+			return renderTemplate(result)
+		case outputEnv:
+			printEnv([]envVar{
+				{"SCHEDULE_ID", result.ScheduleID},
+				{"SCHEDULE_TYPE", result.ScheduleType},
+				{"STATUS", result.Status},
+				{"AGENT_ID", result.AgentID},
+				{"ROLE", result.Role},
+				{"RUN_ID", result.RunID},
+				{"MESSAGE", result.Message},
+				{"EXECUTED_AT", result.ExecutedAt},
+				{"ERROR", result.Error},
+			})
+			return nil
+		}
 
 		fmt.Printf("Schedule ID:   %s\n", result.ScheduleID)
 		fmt.Printf("Schedule Type: %s\n", result.ScheduleType)
+		if result.Status != "" {
+			fmt.Printf("Status:        %s\n", result.Status)
+		}
 		fmt.Printf("Agent ID:      %s\n", result.AgentID)
+		if result.Role != "" {
+			fmt.Printf("Role:          %s\n", result.Role)
+		}
 		fmt.Printf("Run ID:        %s\n", result.RunID)
 		fmt.Printf("Message:       %s\n", result.Message)
-		fmt.Printf("Executed At:   %s\n", result.ExecutedAt)
+		fmt.Printf("Executed At:   %s\n", formatTime(loc, result.ExecutedAt))
+		if result.Error != "" {
+			fmt.Printf("Error:         %s\n", result.Error)
+		}
+
+		return nil
+	},
+}
+
+// fillResultRoles sets the role of results that don't carry one to their
+// schedule's role, looking each schedule type up at most once. The API
+// doesn't record the role with executions, so results whose schedule is gone,
+// like one-time schedules deleted after running, are left without one.
+func fillResultRoles(apiClient *client.Client, results []client.ExecutionResult) {
+	roles := newResultRoles(apiClient)
+	for i := range results {
+		roles.fill(&results[i])
+	}
+}
+
+// resultRoles fills in result roles one at a time, for results that are
+// streamed rather than read into a slice first
+type resultRoles struct {
+	apiClient *client.Client
+	roles     map[string]string
+	loaded    map[string]bool
+}
+
+func newResultRoles(apiClient *client.Client) *resultRoles {
+	return &resultRoles{
+		apiClient: apiClient,
+		roles:     make(map[string]string),
+		loaded:    make(map[string]bool),
+	}
+}
+
+// fill sets r's role to its schedule's role if r doesn't carry one, listing
+// the schedules of r's type the first time that type is seen
+func (rr *resultRoles) fill(r *client.ExecutionResult) {
+	if r.Role != "" {
+		return
+	}
+	if !rr.loaded[r.ScheduleType] {
+		rr.loaded[r.ScheduleType] = true
+		loadScheduleRoles(rr.apiClient, r.ScheduleType, rr.roles)
+	}
+	r.Role = rr.roles[r.ScheduleID]
+}
+
+// loadScheduleRoles adds the role of every schedule of one type to roles.
+// Failing to list them only costs the role column, so errors are ignored.
+func loadScheduleRoles(apiClient *client.Client, scheduleType string, roles map[string]string) {
+	switch scheduleType {
+	case "recurring":
+		schedules, err := apiClient.ListRecurringSchedules()
+		if err != nil {
+			return
+		}
+		for _, s := range schedules {
+			roles[s.ID] = s.Role
+		}
+	case "one-time":
+		schedules, err := apiClient.ListOneTimeSchedules()
+		if err != nil {
+			return
+		}
+		for _, s := range schedules {
+			roles[s.ID] = s.Role
+		}
+	}
+}
+
+var resultsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize execution results",
+	Long: `Count executions per schedule or agent, split into succeeded and failed,
+to spot flaky schedules. Use --since to only count recent executions: it
+takes a window like 24h or 7d, or a time like "2025-11-01T00:00:00Z" or
+"3 days ago", as results export does.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, _ := cmd.Flags().GetString("by")
+		since, _ := cmd.Flags().GetString("since")
+
+		if by != statsBySchedule && by != statsByAgent {
+			return fmt.Errorf("invalid --by value: %s (expected %s or %s)", by, statsBySchedule, statsByAgent)
+		}
+
+		output, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		var cutoff time.Time
+		if since != "" {
+			if cutoff, err = parseResultCutoff(cfg, "since", since); err != nil {
+				return err
+			}
+		}
+		loc, err := displayLocation(cfg)
+		if err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		results, err := apiClient.ListResults()
+		if err != nil {
+			return fmt.Errorf("failed to list results: %w", err)
+		}
+
+		stats := summarizeResults(results, by, cutoff)
+		if len(stats) == 0 && isTable(output) {
+			fmt.Println("No execution results found")
+			return nil
+		}
+
+		rows := [][]string{}
+		for _, s := range stats {
+			rows = append(rows, []string{
+				s.Key,
+				strconv.Itoa(s.Total),
+				strconv.Itoa(s.Succeeded),
+				strconv.Itoa(s.Failed),
+				formatTime(loc, s.LastExecutedAt),
+			})
+		}
+
+		keyHeader := "Schedule ID"
+		if by == statsByAgent {
+			keyHeader = "Agent ID"
+		}
+		columns := []column{
+			{Header: keyHeader},
+			{Header: "Total"},
+			{Header: "Succeeded"},
+			{Header: "Failed"},
+			{Header: "Last Executed"},
+		}
+		return renderList(output, columns, rows, stats)
+	},
+}
+
+var resultsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write execution results to a CSV or JSON file",
+	Long: `Write execution results, with every field, to a CSV or JSON file for
+reporting. The format follows the file extension unless --format is given;
+use --file - to write to stdout.
+
+--since and --until take a window like 24h or 7d, or a time like
+"2025-11-01T00:00:00Z" or "3 days ago".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+		agentID, _ := cmd.Flags().GetString("agent-id")
+		since, _ := cmd.Flags().GetString("since")
+		until, _ := cmd.Flags().GetString("until")
+
+		if file == "" {
+			return fmt.Errorf("--file is required")
+		}
+		if format == "" {
+			format = outputCSV
+			if strings.EqualFold(filepath.Ext(file), ".json") {
+				format = outputJSON
+			}
+		}
+		format = strings.ToLower(format)
+		if format != outputCSV && format != outputJSON {
+			return fmt.Errorf("invalid --format value: %s (expected %s or %s)", format, outputCSV, outputJSON)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		var from, to time.Time
+		if since != "" {
+			if from, err = parseResultCutoff(cfg, "since", since); err != nil {
+				return err
+			}
+		}
+		if until != "" {
+			if to, err = parseResultCutoff(cfg, "until", until); err != nil {
+				return err
+			}
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		results, err := apiClient.ListResults()
+		if err != nil {
+			return fmt.Errorf("failed to list results: %w", err)
+		}
+		results = filterResults(results, agentID, from, to)
+		fillResultRoles(apiClient, results)
+
+		var w io.Writer = os.Stdout
+		if file != "-" {
+			f, err := os.Create(file)
+			if err != nil {
+				return fmt.Errorf("failed to create export file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if format == outputJSON {
+			err = writeResultsJSON(w, results)
+		} else {
+			err = writeResultsCSV(w, results)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
 
+		if file != "-" {
+			color.Green("✓ Exported %d results to %s", len(results), file)
+		}
 		return nil
 	},
 }
 
+// parseResultCutoff parses a --since or --until value: a look-back window
+// like 7d, or anything parseUserTime accepts
+func parseResultCutoff(cfg *config.Config, flag, value string) (time.Time, error) {
+	if window, err := parseWindow(value); err == nil {
+		return time.Now().Add(-window), nil
+	}
+	parsed, err := parseUserTime(cfg, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s value: %s (expected a window like 24h or 7d, or a time)", flag, value)
+	}
+	t, _ := time.Parse(time.RFC3339, parsed)
+	return t, nil
+}
+
+// filterResults keeps the results for agentID, if set, executed between
+// from and to; a zero bound is open. Results without a readable execution
+// time are dropped when either bound is set.
+func filterResults(results []client.ExecutionResult, agentID string, from, to time.Time) []client.ExecutionResult {
+	filtered := []client.ExecutionResult{}
+	for _, r := range results {
+		if agentID != "" && r.AgentID != agentID {
+			continue
+		}
+		if !from.IsZero() || !to.IsZero() {
+			executedAt, ok := parseAPITime(r.ExecutedAt)
+			if !ok || (!from.IsZero() && executedAt.Before(from)) || (!to.IsZero() && executedAt.After(to)) {
+				continue
+			}
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+// resultCSVHeader names the CSV columns written by writeResultsCSV, matching
+// the JSON field names
+var resultCSVHeader = []string{"schedule_id", "schedule_type", "status", "run_id", "agent_id", "role", "message", "executed_at", "error"}
+
+func writeResultsCSV(w io.Writer, results []client.ExecutionResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(resultCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := cw.Write([]string{r.ScheduleID, r.ScheduleType, r.Status, r.RunID, r.AgentID, r.Role, r.Message, r.ExecutedAt, r.Error}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeResultsJSON(w io.Writer, results []client.ExecutionResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+const (
+	statsBySchedule = "schedule"
+	statsByAgent    = "agent"
+)
+
+// resultStats counts the executions of one schedule or agent
+type resultStats struct {
+	Key            string `json:"key"`
+	Total          int    `json:"total"`
+	Succeeded      int    `json:"succeeded"`
+	Failed         int    `json:"failed"`
+	LastExecutedAt string `json:"last_executed_at"`
+
+	// lastExecuted is LastExecutedAt parsed, for comparing
+	lastExecuted time.Time
+}
+
+// summarizeResults groups results by schedule or agent, skipping those
+// executed before cutoff, with the most failures first. Results without a
+// status only count toward the total.
+func summarizeResults(results []client.ExecutionResult, by string, cutoff time.Time) []resultStats {
+	byKey := map[string]*resultStats{}
+	var keys []string
+
+	for _, r := range results {
+		executedAt, parsed := parseAPITime(r.ExecutedAt)
+		if !cutoff.IsZero() && (!parsed || executedAt.Before(cutoff)) {
+			continue
+		}
+
+		key := r.ScheduleID
+		if by == statsByAgent {
+			key = r.AgentID
+		}
+
+		s, ok := byKey[key]
+		if !ok {
+			s = &resultStats{Key: key}
+			byKey[key] = s
+			keys = append(keys, key)
+		}
+
+		s.Total++
+		switch r.Status {
+		case client.ResultStatusSuccess:
+			s.Succeeded++
+		case client.ResultStatusFailed:
+			s.Failed++
+		}
+		switch {
+		case parsed && executedAt.After(s.lastExecuted):
+			s.LastExecutedAt = r.ExecutedAt
+			s.lastExecuted = executedAt
+		case !parsed && s.LastExecutedAt == "":
+			// an unreadable time is shown only until a readable one turns up
+			s.LastExecutedAt = r.ExecutedAt
+		}
+	}
+
+	stats := make([]resultStats, 0, len(keys))
+	for _, key := range keys {
+		stats = append(stats, *byKey[key])
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Failed != stats[j].Failed {
+			return stats[i].Failed > stats[j].Failed
+		}
+		return stats[i].Key < stats[j].Key
+	})
+	return stats
+}
+
+// parseWindow parses a look-back window such as "24h", "90m" or "7d"
+func parseWindow(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid --since value: %s (expected a duration like 24h, 90m or 7d)", s)
+}
+
 func init() {
 	rootCmd.AddCommand(resultsCmd)
 	resultsCmd.AddCommand(resultsListCmd)
+	addOutputFlag(resultsListCmd)
 	resultsCmd.AddCommand(resultsGetCmd)
+	addDetailOutputFlag(resultsGetCmd)
+	resultsCmd.AddCommand(resultsStatsCmd)
+	resultsStatsCmd.Flags().String("by", statsBySchedule, "Group by: schedule, agent")
+	resultsStatsCmd.Flags().String("since", "", "Only count executions at or after this time or within this window, e.g. 7d")
+	addOutputFlag(resultsStatsCmd)
+	resultsCmd.AddCommand(resultsExportCmd)
+	resultsExportCmd.Flags().StringP("file", "f", "", "File to write, e.g. results.csv, or - for stdout (required)")
+	resultsExportCmd.Flags().String("format", "", "File format: csv or json (default from the file extension, else csv)")
+	resultsExportCmd.Flags().String("agent-id", "", "Only export results for this agent")
+	resultsExportCmd.Flags().String("since", "", "Only export executions at or after this time or within this window, e.g. 7d")
+	resultsExportCmd.Flags().String("until", "", "Only export executions at or before this time, e.g. '1 day ago'")
 }
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+)
+
+func TestSummarizeResultsLastExecuted(t *testing.T) {
+	results := []client.ExecutionResult{
+		{ScheduleID: "rs-1", ExecutedAt: "2025-11-01T10:00:00.5Z"},
+		{ScheduleID: "rs-1", ExecutedAt: "2025-11-01T10:00:00Z"},
+		// 09:30 in UTC-1 is 10:30Z, the latest, though it sorts first as text
+		{ScheduleID: "rs-2", ExecutedAt: "2025-11-01T09:30:00-01:00"},
+		{ScheduleID: "rs-2", ExecutedAt: "2025-11-01T10:15:00Z"},
+		{ScheduleID: "rs-3", ExecutedAt: "not a time"},
+		{ScheduleID: "rs-3", ExecutedAt: "2025-11-01T08:00:00Z"},
+	}
+
+	want := map[string]string{
+		"rs-1": "2025-11-01T10:00:00.5Z",
+		"rs-2": "2025-11-01T09:30:00-01:00",
+		"rs-3": "2025-11-01T08:00:00Z",
+	}
+	for _, s := range summarizeResults(results, statsBySchedule, time.Time{}) {
+		if s.LastExecutedAt != want[s.Key] {
+			t.Errorf("%s LastExecutedAt = %q, want %q", s.Key, s.LastExecutedAt, want[s.Key])
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/letta/letta-switchboard-cli/internal/config"
+)
+
+// messageRoles are the roles a scheduled message can be sent as
+var messageRoles = []string{"user", "system", "assistant"}
+
+// validateRole checks a message role against messageRoles
+func validateRole(role string) error {
+	if !contains(messageRoles, role) {
+		return fmt.Errorf("invalid role: %s (expected one of: %s)", role, strings.Join(messageRoles, ", "))
+	}
+	return nil
+}
+
+// resolveRole translates role through the role_aliases config, e.g. "human"
+// to "user", and checks the result against messageRoles
+func resolveRole(cfg *config.Config, role string) (string, error) {
+	if target, ok := cfg.RoleAliases[strings.ToLower(role)]; ok {
+		role = target
+	}
+	if err := validateRole(role); err != nil {
+		if len(cfg.RoleAliases) > 0 {
+			return "", fmt.Errorf("%w, or an alias from role_aliases: %s", err, strings.Join(roleAliasNames(cfg), ", "))
+		}
+		return "", err
+	}
+	return role, nil
+}
+
+// validateRoleAliases checks that every alias maps to a real message role
+func validateRoleAliases(cfg *config.Config) error {
+	for _, alias := range roleAliasNames(cfg) {
+		if err := validateRole(cfg.RoleAliases[alias]); err != nil {
+			return fmt.Errorf("invalid role_aliases entry %q: %w", alias, err)
+		}
+	}
+	return nil
+}
+
+// roleAliasNames returns the configured aliases in sorted order
+func roleAliasNames(cfg *config.Config) []string {
+	names := make([]string, 0, len(cfg.RoleAliases))
+	for alias := range cfg.RoleAliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+	return names
+}
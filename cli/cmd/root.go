@@ -1,33 +1,262 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"time"
 
+	"github.com/letta/letta-switchboard-cli/internal/client"
 	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/letta/letta-switchboard-cli/internal/parser"
 	"github.com/spf13/cobra"
 )
 
+// version is set at build time with -ldflags "-X .../cmd.version=v1.2.3"
+var version = "dev"
+
 var rootCmd = &cobra.Command{
 	Use:   "letta-switchboard",
 	Short: "CLI for routing messages to Letta agents",
 	Long: `Letta Switchboard - Route messages to Letta AI agents
 Send messages immediately or schedule for later. Create recurring
 schedules and view execution results.`,
+	Version: version,
+}
+
+// preRun checks the flags shared by every command before it runs
+func preRun(cmd *cobra.Command, args []string) error {
+	if err := checkFlagConflicts(cmd, args); err != nil {
+		return err
+	}
+	if err := validateTableStyle(); err != nil {
+		return err
+	}
+	for _, name := range []string{"timeout", "timeout-retries"} {
+		if d, _ := cmd.Flags().GetDuration(name); d < 0 {
+			return fmt.Errorf("--%s must not be negative", name)
+		}
+	}
+	if flag := cmd.Flags().Lookup("on-behalf-of"); flag != nil && flag.Changed && strings.TrimSpace(flag.Value.String()) == "" {
+		return fmt.Errorf("--on-behalf-of needs a user ID")
+	}
+	if getCellWidth() < 0 {
+		return fmt.Errorf("--truncate must be 0 (no truncation) or a positive number of characters")
+	}
+	return nil
 }
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	// Cancel in-flight requests and retry waits on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
+
+	// Point at the fix for bad credentials, whichever command hit them
+	var authErr *client.AuthError
+	if errors.As(err, &authErr) {
+		return fmt.Errorf("%w\n\nRun 'letta-switchboard config set-api-key <key>' to set a valid key, or pass --api-key", err)
+	}
+	return err
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+
+	// Set here rather than in the literal since preRun refers back to
+	// rootCmd. Subcommands must not define their own PersistentPreRunE, or
+	// these checks would be skipped for them.
+	rootCmd.PersistentPreRunE = preRun
+
+	rootCmd.PersistentFlags().StringArray("base-url", nil, "API base URL for this invocation (overrides config and LETTA_SWITCHBOARD_BASE_URL)\n  Repeat to fail over to the next URL when one is unreachable")
+	rootCmd.PersistentFlags().String("api-key", "", "API key for this invocation (overrides config and LETTA_SWITCHBOARD_API_KEY)")
+	rootCmd.PersistentFlags().String("env-file", "", "Load LETTA_SWITCHBOARD_* variables from this .env file, e.g. .env (real environment variables win)")
+	rootCmd.PersistentFlags().String("api-key-file", "", "Read the API key from this file (overrides api_key in config)")
+	rootCmd.PersistentFlags().Int("max-retries", client.DefaultMaxRetries, "Retries for rate-limited or transiently failing requests")
+	rootCmd.PersistentFlags().Duration("retry-max-elapsed", 0, "Give up retrying once this much time has passed, e.g. 30s (default no limit)")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Timeout for each request, e.g. 90s (default 1m0s)")
+	rootCmd.PersistentFlags().String("max-response-size", "", "Fail when a response is bigger than this, e.g. 8MB, 512KB, or 0 for no limit (default 8MB)")
+	rootCmd.PersistentFlags().Duration("timeout-retries", 0, "Keep trying for up to this long, e.g. 90s: sets both --timeout and --retry-max-elapsed\n  unless they are given explicitly")
+	rootCmd.PersistentFlags().IntSlice("retry-on", nil, "HTTP statuses to retry, e.g. 429,500,502,503,504 (default 429,502,503,504)")
+	rootCmd.PersistentFlags().Float64("retry-jitter", 0, "Randomize retry waits by up to this fraction, e.g. 0.2 for ±20%")
+	rootCmd.PersistentFlags().Bool("insecure", false, "Skip TLS certificate verification, e.g. for a self-signed dev server (unsafe)")
+	rootCmd.PersistentFlags().String("proxy", "", "Send requests through this proxy, e.g. http://proxy.example.com:3128\n  (default from HTTPS_PROXY, HTTP_PROXY and NO_PROXY)")
+	rootCmd.PersistentFlags().String("on-behalf-of", "", "Act for this user ID by sending an X-On-Behalf-Of header (needs an admin key)")
+	rootCmd.PersistentFlags().String("user-agent", "", "User-Agent header to send (default letta-switchboard-cli/<version>)")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Print request details to stderr")
+	rootCmd.PersistentFlags().Bool("timing", false, "Print how long each API request took (DNS, connect, TLS, first byte, total) to stderr")
+	rootCmd.PersistentFlags().Bool("cache", false, "Reuse cached list results for get commands")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Bypass the list cache")
+	rootCmd.PersistentFlags().String("timezone", "", "Show times in this timezone, e.g. local, UTC, Europe/Berlin (overrides display_timezone)")
+	rootCmd.PersistentFlags().Int("truncate", defaultCellWidth, "Cut table cells longer than this many characters; 0 shows them in full")
+	rootCmd.PersistentFlags().String("table-style", tableStylePlain, "Table layout: "+strings.Join(tableStyles, ", "))
 }
 
+// configStore holds the configuration sources for this invocation
+var configStore *config.Store
+
 func initConfig() {
-	if err := config.InitConfig(); err != nil {
+	// Load the env file first so its variables count as environment
+	// overrides when the store reads them
+	if envFile, _ := rootCmd.PersistentFlags().GetString("env-file"); envFile != "" {
+		if err := config.LoadEnvFile(envFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing config: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	configDir, err := config.GetConfigDir()
+	if err == nil {
+		configStore, err = config.NewStore(configDir)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing config: %v\n", err)
 		os.Exit(1)
 	}
+	flags := map[string]string{
+		"api_key":           "api-key",
+		"api_key_file":      "api-key-file",
+		"max_retries":       "max-retries",
+		"retry_max_elapsed": "retry-max-elapsed",
+		"retry_jitter":      "retry-jitter",
+		"retry_on":          "retry-on",
+		"timeout":           "timeout",
+		"max_response_size": "max-response-size",
+		"insecure":          "insecure",
+		"proxy":             "proxy",
+		"user_agent":        "user-agent",
+		"display_timezone":  "timezone",
+	}
+	for key, name := range flags {
+		if err := configStore.BindFlag(key, rootCmd.PersistentFlags().Lookup(name)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing config: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if baseURLs, _ := rootCmd.PersistentFlags().GetStringArray("base-url"); len(baseURLs) > 0 {
+		configStore.OverrideBaseURLs(baseURLs)
+	}
+}
+
+// loadConfig resolves the configuration for this invocation and checks the
+// settings the CLI interprets itself
+func loadConfig() (*config.Config, error) {
+	cfg, err := configStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRoleAliases(cfg); err != nil {
+		return nil, err
+	}
+	if _, err := resolveRole(cfg, cfg.RecurringDefaultRole); err != nil {
+		return nil, fmt.Errorf("invalid recurring_default_role in config: %w", err)
+	}
+	if _, err := resolveRole(cfg, cfg.OneTimeDefaultRole); err != nil {
+		return nil, fmt.Errorf("invalid onetime_default_role in config: %w", err)
+	}
+	if _, err := cfg.ProxyURL(); err != nil {
+		return nil, err
+	}
+	if _, err := cfg.ResponseSizeLimit(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateRetryOn(); err != nil {
+		return nil, err
+	}
+	if err := validateOutputFormatConfig(cfg); err != nil {
+		return nil, err
+	}
+	if _, err := parser.ParseWeekdayNumbering(cfg.CronWeekdays); err != nil {
+		return nil, fmt.Errorf("invalid cron_weekdays in config: %w", err)
+	}
+	return cfg, nil
+}
+
+// printTiming writes one request's timing to stderr
+func printTiming(t client.RequestTiming) {
+	result := fmt.Sprint(t.StatusCode)
+	if t.Err != nil {
+		result = "failed"
+	}
+
+	var phases []string
+	if t.Reused {
+		phases = append(phases, "reused connection")
+	}
+	// Phases that didn't happen, like DNS for an IP address, are left out
+	for _, phase := range []struct {
+		name string
+		d    time.Duration
+	}{{"dns", t.DNS}, {"connect", t.Connect}, {"tls", t.TLS}, {"first byte", t.TTFB}} {
+		if phase.d > 0 {
+			phases = append(phases, phase.name+" "+formatElapsed(phase.d))
+		}
+	}
+	phases = append(phases, "total "+formatElapsed(t.Total))
+
+	fmt.Fprintf(os.Stderr, "timing: %s %s -> %s: %s\n", t.Method, t.Path, result, strings.Join(phases, ", "))
+}
+
+// formatElapsed rounds a duration for display, to the millisecond above one
+func formatElapsed(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// newAPIClient builds an API client for this invocation from the loaded config
+func newAPIClient(cmd *cobra.Command, cfg *config.Config) *client.Client {
+	endpoints := cfg.Endpoints()
+	var opts []client.Option
+	if cfg.Insecure {
+		fmt.Fprintln(os.Stderr, "Warning: TLS certificate verification is disabled (--insecure); only use this against servers you trust")
+		opts = append(opts, client.WithInsecureTLS())
+	}
+	if proxyURL, _ := cfg.ProxyURL(); proxyURL != nil {
+		opts = append(opts, client.WithProxy(proxyURL))
+	}
+	apiClient := client.NewClient(endpoints[0], cfg.APIKey, opts...).WithContext(cmd.Context())
+	apiClient.FallbackURLs = endpoints[1:]
+	apiClient.MaxRetries = cfg.MaxRetries
+	apiClient.MaxRetryElapsed = cfg.RetryMaxElapsed
+	apiClient.RetryJitter = cfg.RetryJitter
+	apiClient.RetryOn = cfg.RetryOn
+	apiClient.MaxResponseSize, _ = cfg.ResponseSizeLimit()
+	if cfg.Timeout > 0 {
+		apiClient.HTTPClient.Timeout = cfg.Timeout
+	}
+	// One budget for cold starts: the explicit --timeout and
+	// --retry-max-elapsed flags still win over it
+	if budget, _ := cmd.Flags().GetDuration("timeout-retries"); budget > 0 {
+		if !cmd.Flags().Changed("timeout") {
+			apiClient.HTTPClient.Timeout = budget
+		}
+		if !cmd.Flags().Changed("retry-max-elapsed") {
+			apiClient.MaxRetryElapsed = budget
+		}
+	}
+	apiClient.UserAgent = client.DefaultUserAgent + "/" + version
+	if cfg.UserAgent != "" {
+		apiClient.UserAgent = cfg.UserAgent
+	}
+	if onBehalfOf, _ := cmd.Flags().GetString("on-behalf-of"); onBehalfOf != "" {
+		apiClient.OnBehalfOf = strings.TrimSpace(onBehalfOf)
+	}
+	if timing, _ := cmd.Flags().GetBool("timing"); timing {
+		apiClient.OnTiming = printTiming
+	}
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	if verbose {
+		apiClient.Logf = func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		}
+	}
+	apiClient.OnResponse = clockSkewChecker(verbose)
+	return apiClient
 }
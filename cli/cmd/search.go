@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Find schedules by message or agent ID",
+	Long: `List recurring and one-time schedules whose message or agent ID contains
+the query, ignoring case. Matches are highlighted in table output.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := args[0]
+		if strings.TrimSpace(query) == "" {
+			return fmt.Errorf("search query must not be empty")
+		}
+
+		opts, err := getListOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		loc, err := displayLocation(cfg)
+		if err != nil {
+			return err
+		}
+
+		apiClient := newAPIClient(cmd, cfg)
+		apiClient.Scope = opts.scope()
+		return watchList(cmd, func() error {
+			recurring, err := apiClient.ListRecurringSchedules()
+			if err != nil {
+				return fmt.Errorf("failed to list recurring schedules: %w", err)
+			}
+			onetime, err := apiClient.ListOneTimeSchedules()
+			if err != nil {
+				return fmt.Errorf("failed to list one-time schedules: %w", err)
+			}
+
+			if !opts.scoped() {
+				storeCache(cmd, cfg, recurringCacheKey, recurring)
+				storeCache(cmd, cfg, onetimeCacheKey, onetime)
+			}
+			opts.warnUnscoped(recurring, onetime)
+
+			items := []scheduleItem{}
+			for _, s := range mergeSchedules(filterRecurring(recurring, opts), filterOneTime(onetime, opts)) {
+				if containsFold(s.Message, query) || containsFold(s.AgentID, query) {
+					items = append(items, s)
+				}
+			}
+			sortScheduleItems(items, opts.Sort)
+
+			if len(items) == 0 && isTable(opts.Output) {
+				fmt.Printf("No schedules matching %q\n", query)
+				return nil
+			}
+
+			rows := [][]string{}
+			for _, s := range items {
+				rows = append(rows, scheduleItemRow(loc, s))
+			}
+			return renderListStyled(opts.Output, scheduleItemColumns, rows, items, func(cell string) string {
+				return highlight(cell, query)
+			})
+		})
+	},
+}
+
+var matchColor = color.New(color.FgYellow, color.Bold)
+
+// containsFold reports whether s contains substr, ignoring case
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// highlight colors every case-insensitive occurrence of query in s. Colors
+// are dropped automatically when stdout is not a terminal.
+func highlight(s, query string) string {
+	lower, lowerQuery := strings.ToLower(s), strings.ToLower(query)
+	if len(lower) != len(s) || !strings.Contains(lower, lowerQuery) {
+		// Case folding changed byte offsets; show the cell unstyled
+		return s
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, lowerQuery)
+		if i == -1 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(lowerQuery)
+		b.WriteString(s[:i])
+		b.WriteString(matchColor.Sprint(s[i:end]))
+		s, lower = s[end:], lower[end:]
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	addListFlags(searchCmd)
+}
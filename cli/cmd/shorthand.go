@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// applyShorthandArgs fills the create flags from the positional shorthand
+// "[agent-id] <when> <message>", where when stands for whenFlag (cron or
+// execute-at). With two arguments the agent comes from --agent-id or
+// default_agent_id. Flags given explicitly take precedence over the
+// positional values.
+func applyShorthandArgs(cmd *cobra.Command, args []string, whenFlag string) error {
+	var names []string
+	switch len(args) {
+	case 0:
+		return nil
+	case 2:
+		names = []string{whenFlag, "message"}
+	case 3:
+		names = []string{"agent-id", whenFlag, "message"}
+	default:
+		return fmt.Errorf("expected [agent-id] <%s> <message> as arguments, got %d", whenFlag, len(args))
+	}
+
+	for i, name := range names {
+		if cmd.Flags().Changed(name) {
+			continue
+		}
+		if err := cmd.Flags().Set(name, args[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
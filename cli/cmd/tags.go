@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// tagPattern restricts tags to short identifiers that are safe to pass on
+// the command line and in query strings, e.g. "team:ops" or "daily-report"
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:/-]{0,63}$`)
+
+// addTagFlag registers the repeatable --tag flag on a command
+func addTagFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().StringArray("tag", nil, usage)
+}
+
+// getTags returns the validated --tag values, lowercased and de-duplicated
+func getTags(cmd *cobra.Command) ([]string, error) {
+	values, _ := cmd.Flags().GetStringArray("tag")
+	return normalizeTags(values)
+}
+
+// normalizeTags validates tags, lowercasing and de-duplicating them
+func normalizeTags(values []string) ([]string, error) {
+	var tags []string
+	for _, v := range values {
+		tag := strings.ToLower(strings.TrimSpace(v))
+		if !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("invalid tag %q: tags are up to 64 letters, digits, and _ . : / - and must start with a letter or digit", v)
+		}
+		if !contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// hasTags reports whether scheduleTags includes every tag in want
+func hasTags(scheduleTags, want []string) bool {
+	for _, tag := range want {
+		if !contains(scheduleTags, tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// outputTemplate is the output format selected by --template-file
+const outputTemplate = "template"
+
+// itemTemplate is the template loaded from --template-file, set while the
+// --output flag is checked so a broken template fails before any request
+var itemTemplate *template.Template
+
+// templateFuncs are the helpers available to --template-file templates, on
+// top of Go's built-in template functions
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  templateJoin,
+	"default": func(def, value interface{}) interface{} {
+		if value == nil || value == "" {
+			return def
+		}
+		return value
+	},
+	"truncate": func(n int, s string) string { return truncate(s, n) },
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"date": func(layout string, value interface{}) string {
+		if t, ok := templateTime(value); ok {
+			return t.Format(layout)
+		}
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	},
+	"unix": func(value interface{}) int64 {
+		if t, ok := templateTime(value); ok {
+			return t.Unix()
+		}
+		return 0
+	},
+	"ago": func(value interface{}) string {
+		if t, ok := templateTime(value); ok {
+			return time.Since(t).Round(time.Second).String()
+		}
+		return ""
+	},
+}
+
+// addTemplateFlag registers --template-file on a list or get command
+func addTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().String("template-file", "", "Render each item with the Go template in this file instead of --output")
+}
+
+// loadTemplate parses the --template-file template, if one was given
+func loadTemplate(cmd *cobra.Command) (*template.Template, error) {
+	path, _ := cmd.Flags().GetString("template-file")
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := template.New(path).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderTemplate writes one item through itemTemplate, followed by a
+// newline. Fields use the same names as the JSON output, e.g. {{.agent_id}}.
+func renderTemplate(item interface{}) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	var fields interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := itemTemplate.Execute(&buf, fields); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	fmt.Println(strings.TrimSuffix(buf.String(), "\n"))
+	return nil
+}
+
+// renderTemplateList writes each element of the slice items through
+// itemTemplate
+func renderTemplateList(items interface{}) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("failed to render template: %T is not a list", items)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := renderTemplate(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateTime reads an API timestamp passed to a template helper
+func templateTime(value interface{}) (time.Time, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	return parseAPITime(s)
+}
+
+// templateJoin joins a list field, such as tags, with sep
+func templateJoin(sep string, value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	parts := make([]string, len(list))
+	for i, v := range list {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, sep)
+}
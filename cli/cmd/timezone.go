@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/letta/letta-switchboard-cli/internal/parser"
+)
+
+// displayTimeLayout is used for times converted to the display timezone
+const displayTimeLayout = "2006-01-02 15:04:05 -07:00"
+
+// apiTimeLayouts are the timestamp shapes the API returns; those without an
+// offset are UTC
+var apiTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999",
+	"2006-01-02T15:04:05",
+}
+
+// displayLocation resolves the display_timezone setting. It returns nil when
+// unset, meaning times are shown exactly as the API returned them.
+func displayLocation(cfg *config.Config) (*time.Location, error) {
+	switch tz := strings.TrimSpace(cfg.DisplayTimezone); strings.ToLower(tz) {
+	case "":
+		return nil, nil
+	case "local":
+		return time.Local, nil
+	default:
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: use an IANA name like Europe/Berlin, UTC or local", tz)
+		}
+		return loc, nil
+	}
+}
+
+// parseUserTime parses a time given on the command line or in a batch file
+// with parser.ParseTimeIn, reading dates and times of day like "tomorrow at
+// noon" in the display timezone, or UTC when none is set
+func parseUserTime(cfg *config.Config, input string) (string, error) {
+	loc, err := displayLocation(cfg)
+	if err != nil {
+		return "", err
+	}
+	return parser.ParseTimeIn(input, loc)
+}
+
+// parseAPITime parses a timestamp string returned by the API
+func parseAPITime(s string) (time.Time, bool) {
+	for _, layout := range apiTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatTime converts an API timestamp to loc, leaving it untouched when no
+// display timezone is set or the string isn't a recognizable timestamp
+func formatTime(loc *time.Location, s string) string {
+	if loc == nil {
+		return s
+	}
+	if t, ok := parseAPITime(s); ok {
+		return t.In(loc).Format(displayTimeLayout)
+	}
+	return s
+}
+
+// formatAge returns how long ago t was as a short duration like "45m",
+// "3d", "2w", "3mo" or "1y", or "-" when the server didn't return a creation
+// time. Months and years are whole calendar months rather than fixed
+// spans of days, so a schedule created on Mar 15 turns 1mo on Apr 15.
+func formatAge(t client.FlexTime, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	age := now.Sub(t.Time)
+	if age < 0 {
+		age = 0
+	}
+
+	day := 24 * time.Hour
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("%ds", int(age/time.Second))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age/time.Minute))
+	case age < 2*day:
+		return fmt.Sprintf("%dh", int(age/time.Hour))
+	case age < 14*day:
+		return fmt.Sprintf("%dd", int(age/day))
+	}
+
+	months := calendarMonths(t.Time, now)
+	switch {
+	case months < 1:
+		return fmt.Sprintf("%dw", int(age/(7*day)))
+	case months < 12:
+		return fmt.Sprintf("%dmo", months)
+	default:
+		return fmt.Sprintf("%dy", months/12)
+	}
+}
+
+// calendarMonths counts the whole calendar months from start to end, in
+// end's timezone. A month from the 31st ends on the last day of a shorter
+// month, so Jan 31 to Feb 28 is one month.
+func calendarMonths(start, end time.Time) int {
+	start = start.In(end.Location())
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	if months > 0 && addMonths(start, months).After(end) {
+		months--
+	}
+	return months
+}
+
+// addMonths moves t forward n calendar months, clamping the day to the end
+// of a shorter month instead of rolling over like time.AddDate
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// formatFlexTime formats a parsed API time, in loc when one is set
+func formatFlexTime(loc *time.Location, t client.FlexTime) string {
+	if loc == nil {
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return t.In(loc).Format(displayTimeLayout)
+}
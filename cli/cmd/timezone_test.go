@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+)
+
+func TestFormatAge(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		created time.Time
+		now     time.Time
+		want    string
+	}{
+		{"not returned", time.Time{}, date(2025, 3, 1, 0, 0), "-"},
+		{"in the future", date(2025, 3, 2, 0, 0), date(2025, 3, 1, 0, 0), "0s"},
+		{"seconds", date(2025, 3, 1, 0, 0), date(2025, 3, 1, 0, 0).Add(30 * time.Second), "30s"},
+		{"minutes", date(2025, 3, 1, 0, 0), date(2025, 3, 1, 0, 45), "45m"},
+		{"hours", date(2025, 3, 1, 0, 0), date(2025, 3, 2, 23, 59), "47h"},
+		{"two days", date(2025, 3, 1, 0, 0), date(2025, 3, 3, 0, 0), "2d"},
+		{"just under two weeks", date(2025, 3, 1, 0, 0), date(2025, 3, 14, 23, 59), "13d"},
+		{"two weeks", date(2025, 3, 1, 0, 0), date(2025, 3, 15, 0, 0), "2w"},
+		{"just under a month", date(2025, 3, 15, 0, 0), date(2025, 4, 15, 0, 0).Add(-time.Minute), "4w"},
+		{"a month", date(2025, 3, 15, 0, 0), date(2025, 4, 15, 0, 0), "1mo"},
+		{"February is a month", date(2025, 2, 1, 0, 0), date(2025, 3, 1, 0, 0), "1mo"},
+		{"end of January to end of February", date(2025, 1, 31, 12, 0), date(2025, 2, 28, 12, 0), "1mo"},
+		{"end of January to end of leap February", date(2024, 1, 31, 12, 0), date(2024, 2, 29, 12, 0), "1mo"},
+		{"end of January to before end of February", date(2025, 1, 31, 12, 0), date(2025, 2, 27, 12, 0), "3w"},
+		{"end of March to end of April", date(2025, 3, 31, 0, 0), date(2025, 4, 30, 0, 0), "1mo"},
+		{"eleven months", date(2024, 4, 10, 0, 0), date(2025, 3, 10, 0, 0), "11mo"},
+		{"leap day to a year later", date(2024, 2, 29, 0, 0), date(2025, 2, 28, 0, 0), "1y"},
+		{"just under two years", date(2023, 3, 10, 0, 0), date(2025, 3, 9, 0, 0), "1y"},
+		{"two years", date(2023, 3, 10, 0, 0), date(2025, 3, 10, 0, 0), "2y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatAge(client.FlexTime{Time: tt.created}, tt.now)
+			if got != tt.want {
+				t.Errorf("formatAge(%s, %s) = %q, want %q", tt.created, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarMonthsUsesEndTimezone(t *testing.T) {
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+
+	// Feb 28 20:00 UTC is Mar 1 in Tokyo, so a month later in Tokyo is Apr 1
+	start := time.Date(2025, 2, 28, 20, 0, 0, 0, time.UTC)
+	if got := calendarMonths(start, time.Date(2025, 3, 31, 12, 0, 0, 0, tokyo)); got != 0 {
+		t.Errorf("calendarMonths to Mar 31 in Tokyo = %d, want 0", got)
+	}
+	if got := calendarMonths(start, time.Date(2025, 4, 1, 5, 0, 0, 0, tokyo)); got != 1 {
+		t.Errorf("calendarMonths to Apr 1 in Tokyo = %d, want 1", got)
+	}
+}
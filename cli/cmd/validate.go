@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/letta/letta-switchboard-cli/internal/config"
+	"github.com/letta/letta-switchboard-cli/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check cron expressions, times and batch files without calling the API",
+	Long: `Run cron expressions, execution times or a batch file through the same
+parsing and validation as the create commands and print what they resolve
+to. Nothing is sent to the server, so this works offline, e.g. as a CI lint
+step. Exits non-zero if anything is invalid.
+
+  letta-switchboard validate --cron "every monday at 9am" --execute-at "tomorrow at 10am"
+  letta-switchboard validate --file schedules.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		crons, _ := cmd.Flags().GetStringArray("cron")
+		executeAts, _ := cmd.Flags().GetStringArray("execute-at")
+		file, _ := cmd.Flags().GetString("file")
+		allowPast, _ := cmd.Flags().GetBool("allow-past")
+		force, _ := cmd.Flags().GetBool("force")
+		dialectName, _ := cmd.Flags().GetString("cron-dialect")
+		if len(crons) == 0 && len(executeAts) == 0 && file == "" {
+			return fmt.Errorf("nothing to validate: pass --cron, --execute-at or --file")
+		}
+		dialect, err := parser.ParseCronDialect(dialectName)
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		checked, failed := 0, 0
+		report := func(label, input, resolved string, err error) {
+			checked++
+			if err != nil {
+				failed++
+				color.Red("✗ %s %q: %v", label, input, err)
+				return
+			}
+			fmt.Printf("✓ %s %q → %s\n", label, input, resolved)
+		}
+
+		for _, input := range crons {
+			expr, err := parser.ParseCronAs(input, dialect)
+			if err == nil {
+				err = parser.CheckCron(expr, dialect)
+			}
+			if err == nil && !force {
+				err = checkCronFrequency(cfg, expr)
+			}
+			report("cron", input, expr, err)
+		}
+		for _, input := range executeAts {
+			executeAt, err := parseUserTime(cfg, input)
+			if err == nil {
+				err = checkNotPast(executeAt, allowPast)
+			}
+			report("execute-at", input, executeAt, err)
+		}
+
+		if file != "" {
+			checked++
+			if err := validateApplyFile(cfg, file, applyOptions{AllowPast: allowPast, Dialect: dialect, Force: force}); err != nil {
+				failed++
+				color.Red("✗ %s: %v", file, err)
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, checked)
+		}
+		return nil
+	},
+}
+
+// validateApplyFile checks a batch file the way apply does and prints what
+// each entry resolves to
+func validateApplyFile(cfg *config.Config, file string, opts applyOptions) error {
+	data, err := readApplyFile(file)
+	if err != nil {
+		return err
+	}
+	entries, err := parseApplyFile(cfg, data)
+	if err != nil {
+		return err
+	}
+	plan, err := planApply(cfg, entries, opts)
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, p := range plan {
+		if p.Recurring == nil {
+			fmt.Printf("✓ entry %d: one-time at %s\n", p.Index, p.OneTime.ExecuteAt)
+			continue
+		}
+		if err := parser.CheckCron(p.Cron, opts.Dialect); err != nil {
+			invalid++
+			color.Red("✗ entry %d: %v", p.Index, err)
+			continue
+		}
+		fmt.Printf("✓ entry %d: recurring %s\n", p.Index, p.Recurring.CronString)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("invalid batch file: %d of %d entries failed", invalid, len(plan))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().StringArray("cron", nil, "Cron expression or natural language schedule to check (repeatable)")
+	validateCmd.Flags().StringArray("execute-at", nil, "Execution time to check (repeatable)")
+	validateCmd.Flags().StringP("file", "f", "", "Batch file of schedules (YAML or JSON) to check, or - for stdin")
+	validateCmd.Flags().Bool("allow-past", false, "Allow execution times in the past")
+	validateCmd.Flags().Bool("force", false, "Accept crons that fire more often than min_cron_interval, as the create commands and apply do with --force")
+	addCronDialectFlag(validateCmd)
+}
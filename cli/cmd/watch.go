@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultWatchInterval = 5 * time.Second
+	minWatchInterval     = time.Second
+
+	clearScreen = "\033[H\033[2J"
+	hideCursor  = "\033[?25l"
+	showCursor  = "\033[?25h"
+)
+
+// addWatchFlags registers --watch and --interval on a list command
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("watch", "w", false, "Refresh the list until interrupted with Ctrl-C")
+	cmd.Flags().Duration("interval", defaultWatchInterval, "Time between refreshes with --watch")
+}
+
+// watchList calls render once, or with --watch repeatedly on a cleared
+// screen every --interval until the command's context is cancelled. The
+// screen is also redrawn immediately when the terminal is resized. Errors
+// from render are shown in place so a transient failure doesn't end the watch.
+func watchList(cmd *cobra.Command, render func() error) error {
+	watch, _ := cmd.Flags().GetBool("watch")
+	if !watch {
+		return render()
+	}
+
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+	}
+
+	resized := make(chan os.Signal, 1)
+	if sigs := resizeSignals(); len(sigs) > 0 {
+		signal.Notify(resized, sigs...)
+		defer signal.Stop(resized)
+	}
+
+	fmt.Print(hideCursor)
+	defer fmt.Print(showCursor)
+
+	ctx := cmd.Context()
+	title := "Every " + interval.String() + ": " + cmd.CommandPath()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fmt.Print(clearScreen)
+		fmt.Printf("%s    %s\n\n", title, time.Now().Format("15:04:05"))
+		if err := render(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		case <-resized:
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package cmd
+
+import "os"
+
+// resizeSignals returns nothing where there is no resize signal; the next
+// refresh picks up the new size instead
+func resizeSignals() []os.Signal {
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build unix
+
+package cmd
+
+import (
+	"os"
+	"syscall"
+)
+
+// resizeSignals are the signals sent when the terminal is resized
+func resizeSignals() []os.Signal {
+	return []os.Signal{syscall.SIGWINCH}
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirName is the cache directory name inside the config directory
+const DirName = "cache"
+
+// Cache stores list responses on disk for a short time
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// entry is the on-disk representation of a cached response
+type entry struct {
+	CachedAt time.Time       `json:"cached_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// New creates a cache rooted at dir
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{
+		Dir: dir,
+		TTL: ttl,
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Get loads a cached value into v. It reports false if the entry is missing or expired.
+func (c *Cache) Get(key string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		// A corrupt entry is treated as a miss and overwritten on the next Set
+		return false, nil
+	}
+
+	if time.Since(e.CachedAt) > c.TTL {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Set stores v under key
+func (c *Cache) Set(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	raw, err := json.Marshal(entry{CachedAt: time.Now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	if err := os.WriteFile(c.path(key), raw, 0600); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+
+	return nil
+}
+
+// Invalidate removes the entries for the given keys
+func (c *Cache) Invalidate(keys ...string) error {
+	for _, key := range keys {
+		if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to invalidate cache: %w", err)
+		}
+	}
+	return nil
+}
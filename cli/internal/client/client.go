@@ -2,68 +2,677 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	// DefaultMaxRetries is how many times a failed request is retried by default
+	DefaultMaxRetries = 3
+	// DefaultMaxResponseSize is the largest response body read by default
+	DefaultMaxResponseSize = 8 << 20
+	// DefaultUserAgent identifies requests from this client
+	DefaultUserAgent = "letta-switchboard-cli"
+	// maxRetryWait caps how long a single retry waits, including Retry-After
+	maxRetryWait = 60 * time.Second
+)
+
+// DefaultRetryOn are the response statuses retried when RetryOn is empty
+var DefaultRetryOn = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
 // Client handles communication with the Letta Schedules API
 type Client struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+	UserAgent  string
+	// OnBehalfOf, if set, is sent as X-On-Behalf-Of so an admin key can act
+	// for another user
+	OnBehalfOf string
+	// Scope narrows the schedule lists to one owner or team
+	Scope ListScope
+	// FallbackURLs are tried in order when BaseURL is unreachable or returns
+	// a server error for an idempotent request
+	FallbackURLs []string
+	// MaxRetries is how many times a rate-limited or transiently failing request is retried
+	MaxRetries int
+	// MaxRetryElapsed caps the total time spent on a request including
+	// waits between retries; zero means only MaxRetries applies
+	MaxRetryElapsed time.Duration
+	// RetryJitter randomizes each retry wait by up to this fraction (0-1) in
+	// either direction, so many clients don't retry in lockstep
+	RetryJitter float64
+	// RetryOn lists the response statuses to retry, replacing
+	// DefaultRetryOn when set
+	RetryOn []int
+	// MaxResponseSize is the most bytes read from a response body before
+	// giving up with ErrResponseTooLarge; zero means no limit. Streamed
+	// lists aren't buffered and so aren't limited.
+	MaxResponseSize int64
+	// Logf, if set, receives diagnostic messages about each request
+	Logf func(format string, args ...interface{})
+	// OnResponse, if set, is called with every HTTP response received,
+	// including error responses, before its body is read
+	OnResponse func(*http.Response)
+	// OnTiming, if set, is called after every HTTP round trip, including
+	// failed ones and each retry, with how long its phases took
+	OnTiming func(RequestTiming)
+
+	ctx context.Context
+	// ownTransport is the clone options customize, so a transport or HTTP
+	// client passed in by the caller is never changed
+	ownTransport *http.Transport
+	// optionErr records an option that couldn't be applied; every request
+	// fails with it rather than quietly going out without the option
+	optionErr error
+}
+
+// ListScope narrows the schedule lists to an owner or team with the owner
+// and team query parameters. Servers without ownership ignore them and
+// return every schedule the API key can see.
+type ListScope struct {
+	Owner string
+	Team  string
+}
+
+// query returns the scope as a URL query string, or "" when it is empty
+func (s ListScope) query() string {
+	v := url.Values{}
+	if s.Owner != "" {
+		v.Set("owner", s.Owner)
+	}
+	if s.Team != "" {
+		v.Set("team", s.Team)
+	}
+	if len(v) == 0 {
+		return ""
+	}
+	return "?" + v.Encode()
+}
+
+// ErrResponseTooLarge is returned when a response body is bigger than
+// MaxResponseSize, e.g. because the base URL points at a web page
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ErrScheduleModified is returned when an update sent with If-Match is
+// rejected because the schedule changed since it was read
+var ErrScheduleModified = errors.New("schedule was modified since it was read; re-fetch and retry")
+
+// APIError is returned when the API responds with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the server-requested wait from the Retry-After header, if any
+	RetryAfter time.Duration
+	// RequestID is the X-Request-Id header, for correlating with server logs
+	RequestID string
+	// Header holds the debugging headers of the response: X-Request-Id,
+	// Retry-After and the RateLimit-* family
+	Header http.Header
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// AuthError is returned when the API rejects the request's credentials with
+// 401 Unauthorized or 403 Forbidden. It wraps the underlying *APIError.
+type AuthError struct {
+	*APIError
+}
+
+func (e *AuthError) Error() string {
+	msg := fmt.Sprintf("authentication failed (status %d): the API key is missing, invalid, or expired", e.StatusCode)
+	if body := strings.TrimSpace(e.Body); body != "" {
+		msg += " (server said: " + body + ")"
+	}
+	return msg
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.APIError
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient replaces the HTTP client used for requests, e.g. to point
+// tests at a fake server. Options given after it customize a copy, leaving
+// httpClient itself as it was.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTransport sets the RoundTripper used for requests while keeping the
+// default timeout, e.g. to inject a mock transport in tests. Give it before
+// WithInsecureTLS and WithProxy, which need an *http.Transport and customize
+// a clone of it.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		httpClient := *c.HTTPClient
+		httpClient.Transport = transport
+		c.HTTPClient = &httpClient
+	}
+}
+
+// WithInsecureTLS turns off TLS certificate verification, for servers with
+// self-signed certificates during local development only
+func WithInsecureTLS() Option {
+	return func(c *Client) {
+		if t := c.transport("WithInsecureTLS"); t != nil {
+			t.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		}
+	}
+}
+
+// WithProxy sends every request through proxyURL instead of the proxy, if
+// any, chosen by HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Client) {
+		if t := c.transport("WithProxy"); t != nil {
+			t.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+}
+
+// transport returns the *http.Transport for options to customize. The
+// first time, it clones the client's transport, or the default one, and
+// copies the HTTP client around it, so neither http.DefaultTransport nor a
+// transport or client the caller passed in is changed. The clone keeps the
+// proxy handling from the environment, and sharing it lets options like
+// WithInsecureTLS and WithProxy combine in any order. A RoundTripper that
+// isn't an *http.Transport can't be customized: option is recorded as
+// failed and nil is returned.
+func (c *Client) transport(option string) *http.Transport {
+	var transport *http.Transport
+	switch t := c.HTTPClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		if t == c.ownTransport {
+			return t
+		}
+		transport = t.Clone()
+	default:
+		if c.optionErr == nil {
+			c.optionErr = fmt.Errorf("%s needs an *http.Transport, but the client uses a %T", option, t)
+		}
+		return nil
+	}
+
+	httpClient := *c.HTTPClient
+	httpClient.Transport = transport
+	c.HTTPClient = &httpClient
+	c.ownTransport = transport
+	return transport
+}
+
+// WithMaxRetryElapsed caps the total time spent retrying a request
+func WithMaxRetryElapsed(d time.Duration) Option {
+	return func(c *Client) {
+		c.MaxRetryElapsed = d
+	}
+}
+
+// WithRetryJitter randomizes retry waits by up to fraction in either direction
+func WithRetryJitter(fraction float64) Option {
+	return func(c *Client) {
+		c.RetryJitter = fraction
+	}
 }
 
 // NewClient creates a new API client
-func NewClient(baseURL, apiKey string) *Client {
-	return &Client{
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
 		BaseURL: baseURL,
 		APIKey:  apiKey,
 		HTTPClient: &http.Client{
 			Timeout: 60 * time.Second, // Increased for Modal cold starts
 		},
+		UserAgent:       DefaultUserAgent,
+		MaxRetries:      DefaultMaxRetries,
+		MaxResponseSize: DefaultMaxResponseSize,
+		ctx:             context.Background(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithContext returns a shallow copy of the client whose requests, including
+// waits between retries, are bound to ctx
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
 }
 
-// doRequest executes an HTTP request
+// doRequest executes an HTTP request and returns the full response body
 func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
+	respBody, _, err := c.doRequestWithHeader(method, path, body, nil)
+	return respBody, err
+}
+
+// doRequestWithHeader is doRequest with extra request headers, also
+// returning the response headers
+func (c *Client) doRequestWithHeader(method, path string, body interface{}, header http.Header) ([]byte, http.Header, error) {
+	resp, err := c.do(method, path, body, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := c.readBody(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return respBody, resp.Header, nil
+}
+
+// readBody reads a whole response body, stopping with ErrResponseTooLarge
+// once it passes MaxResponseSize rather than buffering all of it
+func (c *Client) readBody(r io.Reader) ([]byte, error) {
+	if c.MaxResponseSize <= 0 {
+		return io.ReadAll(r)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, c.MaxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > c.MaxResponseSize {
+		return nil, fmt.Errorf("%w: more than %d bytes; check the base URL points at the API", ErrResponseTooLarge, c.MaxResponseSize)
+	}
+	return data, nil
+}
+
+// do executes an HTTP request with any extra headers, retrying rate-limited
+// and transient failures. On success the caller must close the response body.
+func (c *Client) do(method, path string, body interface{}, header http.Header) (*http.Response, error) {
+	if c.optionErr != nil {
+		return nil, c.optionErr
+	}
+
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendWithFailover(ctx, method, path, jsonData, header)
+		if err == nil {
+			return resp, nil
+		}
+
+		if !c.shouldRetry(method, err) || ctx.Err() != nil {
+			return nil, err
+		}
+		if attempt >= c.MaxRetries {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+				return nil, fmt.Errorf("still rate limited after %d retries: %w", attempt, err)
+			}
+			return nil, err
+		}
+
+		wait := jitter(retryWait(attempt, err), c.RetryJitter)
+		if c.MaxRetryElapsed > 0 {
+			elapsed := time.Since(start)
+			if elapsed+wait > c.MaxRetryElapsed {
+				c.logf("%s %s failed (%v), retry budget of %s exhausted after %s", method, path, err, c.MaxRetryElapsed, elapsed.Round(time.Millisecond))
+				return nil, fmt.Errorf("gave up after %d retries in %s (retry budget %s): %w", attempt, elapsed.Round(time.Millisecond), c.MaxRetryElapsed, err)
+			}
+			c.logf("%s %s failed (%v), retrying in %s (attempt %d of %d, %s of %s budget used)", method, path, err, wait, attempt+1, c.MaxRetries, elapsed.Round(time.Millisecond), c.MaxRetryElapsed)
+		} else {
+			c.logf("%s %s failed (%v), retrying in %s (attempt %d of %d)", method, path, err, wait, attempt+1, c.MaxRetries)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sendWithFailover tries each endpoint in turn until one serves the request
+func (c *Client) sendWithFailover(ctx context.Context, method, path string, jsonData []byte, header http.Header) (*http.Response, error) {
+	endpoints := append([]string{c.BaseURL}, c.FallbackURLs...)
+
+	var err error
+	for i, baseURL := range endpoints {
+		var resp *http.Response
+		resp, err = c.send(ctx, baseURL, method, path, jsonData, header)
+		if err == nil {
+			c.logf("%s %s served by %s", method, path, baseURL)
+			return resp, nil
+		}
+
+		if i == len(endpoints)-1 || !shouldFailover(method, err) || ctx.Err() != nil {
+			break
+		}
+		c.logf("%s %s failed on %s (%v), failing over to %s", method, path, baseURL, err, endpoints[i+1])
+	}
+
+	return nil, err
+}
+
+// send performs a single HTTP round trip against baseURL. Non-2xx responses
+// are read and returned as an *APIError; otherwise the body is left open.
+func (c *Client) send(ctx context.Context, baseURL, method, path string, jsonData []byte, header http.Header) (*http.Response, error) {
+	var reqBody io.Reader
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	// Setting this ourselves turns off the transport's own decompression,
+	// so gzip bodies are decoded below
+	req.Header.Set("Accept-Encoding", "gzip")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 	if c.APIKey != "" {
 		req.Header.Set("Authorization", "Bearer "+c.APIKey)
 	}
+	if c.OnBehalfOf != "" {
+		req.Header.Set("X-On-Behalf-Of", c.OnBehalfOf)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	var timer *requestTimer
+	if c.OnTiming != nil {
+		timer = newRequestTimer(method, path)
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), timer.trace()))
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
+		if timer != nil {
+			timer.finish(c.OnTiming, 0, err)
+		}
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
+	if timer != nil {
+		statusCode := resp.StatusCode
+		resp.Body = &timedBody{ReadCloser: resp.Body, onClose: func() {
+			timer.finish(c.OnTiming, statusCode, nil)
+		}}
+	}
+
+	c.logResponse(method, path, resp)
+	if c.OnResponse != nil {
+		c.OnResponse(resp)
+	}
+
+	if err := decompressBody(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		respBody, err := c.readBody(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Body:       string(respBody),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RequestID:  resp.Header.Get("X-Request-Id"),
+			Header:     DebugHeaders(resp.Header),
+		}
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return nil, &AuthError{APIError: apiErr}
+		}
+		return nil, apiErr
+	}
+
+	return resp, nil
+}
+
+// gzipBody reads a gzip-encoded response, closing the underlying body too
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// decompressBody replaces a gzip-encoded response body with its decoded
+// contents, so callers always read plain JSON
+func decompressBody(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if errors.Is(err, io.EOF) {
+		// An empty body has nothing to decompress
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decompress response: %w", err)
+	}
+	resp.Body = &gzipBody{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// streamList decodes a JSON array response one element at a time, so large
+// lists are never buffered whole. each is called with the decoder positioned
+// at the next element and must decode exactly one value.
+func (c *Client) streamList(path string, each func(dec *json.Decoder) error) error {
+	resp, err := c.do("GET", path, nil, nil)
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	dec := json.NewDecoder(resp.Body)
+	tok, err := dec.Token()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("failed to parse response: expected a JSON array")
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	count := 0
+	for dec.More() {
+		if err := each(dec); err != nil {
+			return fmt.Errorf("failed to parse response after %d items: %w", count, err)
+		}
+		count++
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to parse response after %d items: %w", count, err)
+	}
+
+	return nil
+}
+
+// shouldRetry reports whether a failed request is safe and worthwhile to
+// retry. Only statuses in RetryOn (or DefaultRetryOn) are retried. Rate
+// limiting is retried for any method since the server did not process the
+// request; other statuses and network failures only for idempotent methods.
+func (c *Client) shouldRetry(method string, err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		retryOn := c.RetryOn
+		if len(retryOn) == 0 {
+			retryOn = DefaultRetryOn
+		}
+		for _, code := range retryOn {
+			if code == apiErr.StatusCode {
+				return code == http.StatusTooManyRequests || isIdempotent(method)
+			}
+		}
+		return false
+	}
+	return isIdempotent(method)
+}
+
+// shouldFailover reports whether a failed request should be tried on the next
+// endpoint: connection failures and server errors, for idempotent methods only
+func shouldFailover(method string, err error) bool {
+	if !isIdempotent(method) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodDelete
+}
+
+// retryWait returns how long to wait before the next attempt, preferring the
+// server's Retry-After and otherwise backing off exponentially from one second
+func retryWait(attempt int, err error) time.Duration {
+	wait := time.Second << attempt
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		wait = apiErr.RetryAfter
 	}
 
-	return respBody, nil
+	if wait > maxRetryWait {
+		wait = maxRetryWait
+	}
+	return wait
+}
+
+// jitter spreads wait randomly by up to fraction in either direction
+func jitter(wait time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return wait
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	delta := (rand.Float64()*2 - 1) * fraction * float64(wait)
+	return wait + time.Duration(delta)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP-date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(t); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// DebugHeaders returns the response headers useful for diagnosing throttling
+// and correlating requests with server logs: X-Request-Id, Retry-After and
+// any RateLimit-* or X-RateLimit-* header
+func DebugHeaders(h http.Header) http.Header {
+	debug := http.Header{}
+	for name, values := range h {
+		canonical := http.CanonicalHeaderKey(name)
+		switch {
+		case canonical == "X-Request-Id",
+			canonical == "Retry-After",
+			strings.HasPrefix(canonical, "Ratelimit-"),
+			strings.HasPrefix(canonical, "X-Ratelimit-"):
+			debug[canonical] = values
+		}
+	}
+	return debug
+}
+
+// logResponse logs the status and debugging headers of a response
+func (c *Client) logResponse(method, path string, resp *http.Response) {
+	if c.Logf == nil {
+		return
+	}
+
+	c.logf("%s %s -> %s", method, path, resp.Status)
+
+	headers := DebugHeaders(resp.Header)
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.logf("  %s: %s", name, strings.Join(headers[name], ", "))
+	}
+}
+
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.Logf != nil {
+		c.Logf(format, args...)
+	}
+}
+
+// sleep waits for d or until ctx is cancelled
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // Recurring Schedule methods
@@ -83,23 +692,50 @@ func (c *Client) CreateRecurringSchedule(schedule RecurringScheduleCreate) (*Rec
 }
 
 func (c *Client) ListRecurringSchedules() ([]RecurringSchedule, error) {
-	respBody, err := c.doRequest("GET", "/schedules/recurring", nil)
+	schedules := []RecurringSchedule{}
+	err := c.EachRecurringSchedule(func(s RecurringSchedule) error {
+		schedules = append(schedules, s)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return schedules, nil
+}
 
-	var schedules []RecurringSchedule
-	if err := json.Unmarshal(respBody, &schedules); err != nil {
+// EachRecurringSchedule streams recurring schedules to fn as they are decoded.
+// Returning an error from fn stops the iteration.
+func (c *Client) EachRecurringSchedule(fn func(RecurringSchedule) error) error {
+	return c.streamList("/schedules/recurring"+c.Scope.query(), func(dec *json.Decoder) error {
+		var s RecurringSchedule
+		if err := dec.Decode(&s); err != nil {
+			return err
+		}
+		return fn(s)
+	})
+}
+
+func (c *Client) GetRecurringSchedule(scheduleID string) (*RecurringSchedule, error) {
+	respBody, header, err := c.doRequestWithHeader("GET", "/schedules/recurring/"+scheduleID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule RecurringSchedule
+	if err := json.Unmarshal(respBody, &schedule); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	schedule.ETag = header.Get("ETag")
 
-	return schedules, nil
+	return &schedule, nil
 }
 
-func (c *Client) GetRecurringSchedule(scheduleID string) (*RecurringSchedule, error) {
-	respBody, err := c.doRequest("GET", "/schedules/recurring/"+scheduleID, nil)
+// UpdateRecurringSchedule changes the fields set in update, e.g. Enabled to
+// pause or resume a schedule
+func (c *Client) UpdateRecurringSchedule(scheduleID string, update RecurringScheduleUpdate) (*RecurringSchedule, error) {
+	respBody, _, err := c.doRequestWithHeader("PATCH", "/schedules/recurring/"+scheduleID, update, ifMatchHeader(update.IfMatch))
 	if err != nil {
-		return nil, err
+		return nil, modifiedError(err)
 	}
 
 	var schedule RecurringSchedule
@@ -132,23 +768,50 @@ func (c *Client) CreateOneTimeSchedule(schedule OneTimeScheduleCreate) (*OneTime
 }
 
 func (c *Client) ListOneTimeSchedules() ([]OneTimeSchedule, error) {
-	respBody, err := c.doRequest("GET", "/schedules/one-time", nil)
+	schedules := []OneTimeSchedule{}
+	err := c.EachOneTimeSchedule(func(s OneTimeSchedule) error {
+		schedules = append(schedules, s)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return schedules, nil
+}
 
-	var schedules []OneTimeSchedule
-	if err := json.Unmarshal(respBody, &schedules); err != nil {
+// EachOneTimeSchedule streams one-time schedules to fn as they are decoded.
+// Returning an error from fn stops the iteration.
+func (c *Client) EachOneTimeSchedule(fn func(OneTimeSchedule) error) error {
+	return c.streamList("/schedules/one-time"+c.Scope.query(), func(dec *json.Decoder) error {
+		var s OneTimeSchedule
+		if err := dec.Decode(&s); err != nil {
+			return err
+		}
+		return fn(s)
+	})
+}
+
+func (c *Client) GetOneTimeSchedule(scheduleID string) (*OneTimeSchedule, error) {
+	respBody, header, err := c.doRequestWithHeader("GET", "/schedules/one-time/"+scheduleID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule OneTimeSchedule
+	if err := json.Unmarshal(respBody, &schedule); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	schedule.ETag = header.Get("ETag")
 
-	return schedules, nil
+	return &schedule, nil
 }
 
-func (c *Client) GetOneTimeSchedule(scheduleID string) (*OneTimeSchedule, error) {
-	respBody, err := c.doRequest("GET", "/schedules/one-time/"+scheduleID, nil)
+// UpdateOneTimeSchedule changes the fields set in update, e.g. AgentID to
+// move a schedule to another agent
+func (c *Client) UpdateOneTimeSchedule(scheduleID string, update OneTimeScheduleUpdate) (*OneTimeSchedule, error) {
+	respBody, _, err := c.doRequestWithHeader("PATCH", "/schedules/one-time/"+scheduleID, update, ifMatchHeader(update.IfMatch))
 	if err != nil {
-		return nil, err
+		return nil, modifiedError(err)
 	}
 
 	var schedule OneTimeSchedule
@@ -167,19 +830,29 @@ func (c *Client) DeleteOneTimeSchedule(scheduleID string) error {
 // Results methods
 
 func (c *Client) ListResults() ([]ExecutionResult, error) {
-	respBody, err := c.doRequest("GET", "/results", nil)
+	results := []ExecutionResult{}
+	err := c.EachResult(func(r ExecutionResult) error {
+		results = append(results, r)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	var results []ExecutionResult
-	if err := json.Unmarshal(respBody, &results); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
 	return results, nil
 }
 
+// EachResult streams execution results to fn as they are decoded.
+// Returning an error from fn stops the iteration.
+func (c *Client) EachResult(fn func(ExecutionResult) error) error {
+	return c.streamList("/results", func(dec *json.Decoder) error {
+		var r ExecutionResult
+		if err := dec.Decode(&r); err != nil {
+			return err
+		}
+		return fn(r)
+	})
+}
+
 func (c *Client) GetResult(scheduleID string) (*ExecutionResult, error) {
 	respBody, err := c.doRequest("GET", "/results/"+scheduleID, nil)
 	if err != nil {
@@ -193,3 +866,22 @@ func (c *Client) GetResult(scheduleID string) (*ExecutionResult, error) {
 
 	return &result, nil
 }
+
+// ifMatchHeader returns the If-Match header for an update, or nil when no
+// ETag was captured
+func ifMatchHeader(etag string) http.Header {
+	if etag == "" {
+		return nil
+	}
+	return http.Header{"If-Match": {etag}}
+}
+
+// modifiedError turns a 412 Precondition Failed into ErrScheduleModified,
+// keeping the API error for details
+func modifiedError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed {
+		return fmt.Errorf("%w (%v)", ErrScheduleModified, err)
+	}
+	return err
+}
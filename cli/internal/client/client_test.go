@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// gzipped compresses s as a server would before sending it
+func gzipped(t testing.TB, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newTestClient returns a client for server that fails instead of retrying
+func newTestClient(server *httptest.Server) *Client {
+	c := NewClient(server.URL, "test-key", WithHTTPClient(server.Client()))
+	c.MaxRetries = 0
+	return c
+}
+
+func TestGzipResponse(t *testing.T) {
+	body := gzipped(t, `{"id":"rs-1","agent_id":"agent-1","message":"hello","role":"user","cron":"0 9 * * *"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", got)
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	schedule, err := newTestClient(server).GetRecurringSchedule("rs-1")
+	if err != nil {
+		t.Fatalf("GetRecurringSchedule returned error: %v", err)
+	}
+	if schedule.ID != "rs-1" || schedule.Message != "hello" || schedule.CronString != "0 9 * * *" {
+		t.Errorf("GetRecurringSchedule = %+v, want the decoded schedule", schedule)
+	}
+	if schedule.ETag != `"v1"` {
+		t.Errorf("ETag = %q, want %q", schedule.ETag, `"v1"`)
+	}
+}
+
+func TestGzipStreamedList(t *testing.T) {
+	body := gzipped(t, `[{"id":"rs-1","cron":"0 9 * * *"},{"id":"rs-2","cron":"*/5 * * * *"}]`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	schedules, err := newTestClient(server).ListRecurringSchedules()
+	if err != nil {
+		t.Fatalf("ListRecurringSchedules returned error: %v", err)
+	}
+	if len(schedules) != 2 || schedules[0].ID != "rs-1" || schedules[1].ID != "rs-2" {
+		t.Errorf("ListRecurringSchedules = %+v, want rs-1 and rs-2", schedules)
+	}
+}
+
+func TestGzipErrorResponse(t *testing.T) {
+	body := gzipped(t, `{"detail":"schedule not found"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetRecurringSchedule("missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetRecurringSchedule error = %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || !strings.Contains(apiErr.Body, "schedule not found") {
+		t.Errorf("APIError = %d %q, want 404 with the decoded body", apiErr.StatusCode, apiErr.Body)
+	}
+}
+
+func TestGzipEmptyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := newTestClient(server).DeleteRecurringSchedule("rs-1"); err != nil {
+		t.Errorf("DeleteRecurringSchedule returned error: %v", err)
+	}
+}
+
+func TestCorruptGzipResponse(t *testing.T) {
+	valid := gzipped(t, `{"id":"rs-1","message":"hello"}`)
+
+	tests := []struct {
+		name string
+		body []byte
+		want string
+	}{
+		{"not gzip at all", []byte(`{"id":"rs-1"}`), "failed to decompress response"},
+		{"truncated", valid[:len(valid)-10], "failed to read response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", "gzip")
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			schedule, err := newTestClient(server).GetRecurringSchedule("rs-1")
+			if err == nil {
+				t.Fatalf("GetRecurringSchedule = %+v, want an error", schedule)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("GetRecurringSchedule error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// scheduleListBody returns a JSON array of n recurring schedules
+func scheduleListBody(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"id":"rs-%d","agent_id":"agent-%d","message":"Daily check-in number %d","role":"user","cron":"0 9 * * *","tags":["bench"],"created_at":"2025-01-15T10:00:00Z"}`, i, i%50, i)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
+
+func BenchmarkEachRecurringSchedule(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		body := scheduleListBody(n)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(body)
+		}))
+		c := newTestClient(server)
+
+		b.Run(fmt.Sprintf("stream/%d", n), func(b *testing.B) {
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				count := 0
+				err := c.EachRecurringSchedule(func(RecurringSchedule) error {
+					count++
+					return nil
+				})
+				if err != nil || count != n {
+					b.Fatalf("EachRecurringSchedule = %d schedules, %v; want %d", count, err, n)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("list/%d", n), func(b *testing.B) {
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				schedules, err := c.ListRecurringSchedules()
+				if err != nil || len(schedules) != n {
+					b.Fatalf("ListRecurringSchedules = %d schedules, %v; want %d", len(schedules), err, n)
+				}
+			}
+		})
+
+		server.Close()
+	}
+}
+
+func TestTransportOptionsLeaveCallerTransportAlone(t *testing.T) {
+	proxyURL, _ := url.Parse("http://proxy.example:3128")
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	defaultTLS, defaultProxy := defaultTransport.TLSClientConfig, defaultTransport.Proxy
+
+	c := NewClient("https://switchboard.example", "test-key", WithTransport(http.DefaultTransport), WithInsecureTLS(), WithProxy(proxyURL))
+	if defaultTransport.TLSClientConfig != defaultTLS || fmt.Sprintf("%p", defaultTransport.Proxy) != fmt.Sprintf("%p", defaultProxy) {
+		t.Fatal("WithInsecureTLS and WithProxy changed http.DefaultTransport")
+	}
+	transport, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok || transport == defaultTransport {
+		t.Fatalf("client transport = %T %p, want a clone of http.DefaultTransport", c.HTTPClient.Transport, c.HTTPClient.Transport)
+	}
+	if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("client transport doesn't skip TLS verification")
+	}
+
+	callerClient := &http.Client{}
+	c = NewClient("https://switchboard.example", "test-key", WithHTTPClient(callerClient), WithProxy(proxyURL))
+	if callerClient.Transport != nil {
+		t.Errorf("WithProxy set the caller's http.Client transport to %T", callerClient.Transport)
+	}
+	if c.HTTPClient == callerClient {
+		t.Error("WithProxy customized the caller's http.Client instead of a copy")
+	}
+}
+
+func TestTransportOptionsOnCustomRoundTripper(t *testing.T) {
+	roundTripper := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("request sent without the --insecure option")
+		return nil, nil
+	})
+
+	c := NewClient("https://switchboard.example", "test-key", WithTransport(roundTripper), WithInsecureTLS())
+	c.MaxRetries = 0
+	_, err := c.GetRecurringSchedule("rs-1")
+	if err == nil || !strings.Contains(err.Error(), "WithInsecureTLS needs an *http.Transport") {
+		t.Errorf("GetRecurringSchedule error = %v, want WithInsecureTLS reported as not applied", err)
+	}
+}
+
+// roundTripperFunc lets a plain function stand in for an http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClockSkew returns how far the local clock, read as now when resp arrived,
+// is ahead of the server's according to its Date header; negative means
+// behind. The header has one-second precision and is written before the
+// response travels back, so differences of a second or two are noise. ok is
+// false when the response has no valid Date header.
+func ClockSkew(resp *http.Response, now time.Time) (skew time.Duration, ok bool) {
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, false
+	}
+	return now.Sub(date), true
+}
@@ -0,0 +1,62 @@
+package client_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/letta/letta-switchboard-cli/internal/client"
+)
+
+// roundTripFunc lets a plain function stand in for an http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// A fake server from net/http/httptest can be injected with WithHTTPClient.
+func ExampleWithHTTPClient() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"rs-1","agent_id":"agent-1","cron":"0 9 * * 1-5"}]`)
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-key", client.WithHTTPClient(server.Client()))
+	schedules, err := c.ListRecurringSchedules()
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	for _, s := range schedules {
+		fmt.Println(s.ID, s.CronString)
+	}
+	// Output: rs-1 0 9 * * 1-5
+}
+
+// A RoundTripper injected with WithTransport sees every request and can
+// answer without any network, keeping the client's default timeout.
+func ExampleWithTransport() {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		fmt.Println(req.Method, req.URL.Path, req.Header.Get("Authorization"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id":"ot-1","execute_at":"2025-01-16T09:00:00Z"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	c := client.NewClient("https://switchboard.example", "test-key", client.WithTransport(transport))
+	schedule, err := c.GetOneTimeSchedule("ot-1")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(schedule.ID, schedule.ExecuteAt)
+	// Output:
+	// GET /schedules/one-time/ot-1 Bearer test-key
+	// ot-1 2025-01-16T09:00:00Z
+}
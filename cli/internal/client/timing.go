@@ -0,0 +1,108 @@
+package client
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// RequestTiming breaks down how long one HTTP round trip took. The phase
+// durations are zero when a phase didn't happen, e.g. DNS and Connect on a
+// reused connection.
+type RequestTiming struct {
+	Method string
+	Path   string
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	// Err is set when the request failed before a response arrived
+	Err error
+
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+	// TTFB is the time from sending the request to the first response byte
+	TTFB time.Duration
+	// Total runs until the response body is closed, so it includes reading
+	// the whole body
+	Total time.Duration
+	// Reused is set when the request went over a kept-alive connection
+	Reused bool
+}
+
+// requestTimer records the phases of one request through httptrace
+type requestTimer struct {
+	mu     sync.Mutex
+	timing RequestTiming
+	start  time.Time
+
+	dnsStart, connectStart, tlsStart time.Time
+	done                             bool
+}
+
+func newRequestTimer(method, path string) *requestTimer {
+	return &requestTimer{timing: RequestTiming{Method: method, Path: path}, start: time.Now()}
+}
+
+func (t *requestTimer) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.since(t.dnsStart, &t.timing.DNS) },
+		ConnectStart: func(string, string) {
+			t.mark(&t.connectStart)
+		},
+		ConnectDone: func(string, string, error) { t.since(t.connectStart, &t.timing.Connect) },
+		TLSHandshakeStart: func() {
+			t.mark(&t.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) { t.since(t.tlsStart, &t.timing.TLS) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.timing.Reused = info.Reused
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() { t.since(t.start, &t.timing.TTFB) },
+	}
+}
+
+func (t *requestTimer) mark(at *time.Time) {
+	t.mu.Lock()
+	*at = time.Now()
+	t.mu.Unlock()
+}
+
+func (t *requestTimer) since(from time.Time, d *time.Duration) {
+	t.mu.Lock()
+	*d = time.Since(from)
+	t.mu.Unlock()
+}
+
+// finish reports the timing to fn once, with the status code or error
+func (t *requestTimer) finish(fn func(RequestTiming), statusCode int, err error) {
+	t.mu.Lock()
+	if t.done {
+		t.mu.Unlock()
+		return
+	}
+	t.done = true
+	t.timing.StatusCode = statusCode
+	t.timing.Err = err
+	t.timing.Total = time.Since(t.start)
+	timing := t.timing
+	t.mu.Unlock()
+
+	fn(timing)
+}
+
+// timedBody reports a request's timing when its response body is closed
+type timedBody struct {
+	io.ReadCloser
+	onClose func()
+}
+
+func (b *timedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.onClose()
+	return err
+}
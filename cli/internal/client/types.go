@@ -49,47 +49,131 @@ func (ft FlexTime) MarshalJSON() ([]byte, error) {
 
 // RecurringSchedule represents a recurring schedule
 type RecurringSchedule struct {
-	ID         string   `json:"id"`
-	AgentID    string   `json:"agent_id"`
-	Message    string   `json:"message"`
-	Role       string   `json:"role"`
-	CronString string   `json:"cron"`
-	LastRun    *string  `json:"last_run,omitempty"`
-	CreatedAt  FlexTime `json:"created_at"`
+	ID      string `json:"id"`
+	AgentID string `json:"agent_id"`
+	// Name and Description are optional labels for people; servers that
+	// don't store them return neither
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Message     string   `json:"message"`
+	Role        string   `json:"role"`
+	CronString  string   `json:"cron"`
+	StartAt     string   `json:"start_at,omitempty"`
+	EndAt       string   `json:"end_at,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	LastRun     *string  `json:"last_run,omitempty"`
+	CreatedAt   FlexTime `json:"created_at"`
+	// UpdatedAt and PausedAt are only returned by servers that track state changes
+	UpdatedAt *FlexTime `json:"updated_at,omitempty"`
+	PausedAt  *FlexTime `json:"paused_at,omitempty"`
+
+	// Messages and MessageRotation are only returned by servers that rotate
+	// through several messages, sending one per run
+	Messages        []string `json:"messages,omitempty"`
+	MessageRotation string   `json:"message_rotation,omitempty"`
+
+	// Owner and Team are only returned by servers that scope schedules to
+	// users or teams
+	Owner string `json:"owner,omitempty"`
+	Team  string `json:"team,omitempty"`
+
+	// ETag is the version the server reported when the schedule was fetched
+	// on its own; empty when the server doesn't send one
+	ETag string `json:"-"`
+
+	// Enabled is only returned by servers that report whether a schedule is
+	// running; false means it is paused
+	Enabled *bool `json:"enabled,omitempty"`
 }
 
 // RecurringScheduleCreate represents the payload to create a recurring schedule
 type RecurringScheduleCreate struct {
-	AgentID    string `json:"agent_id"`
-	Message    string `json:"message"`
-	Role       string `json:"role"`
-	CronString string `json:"cron"`
+	AgentID     string   `json:"agent_id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Message     string   `json:"message"`
+	Role        string   `json:"role"`
+	CronString  string   `json:"cron"`
+	StartAt     string   `json:"start_at,omitempty"`
+	EndAt       string   `json:"end_at,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+
+	// Messages and MessageRotation ask for several messages sent in turn;
+	// the bundled server doesn't support them yet, so the CLI never sets them
+	Messages        []string `json:"messages,omitempty"`
+	MessageRotation string   `json:"message_rotation,omitempty"`
+}
+
+// RecurringScheduleUpdate represents the payload to change a recurring
+// schedule; nil fields are left as they are
+type RecurringScheduleUpdate struct {
+	Enabled *bool  `json:"enabled,omitempty"`
+	AgentID string `json:"agent_id,omitempty"`
+
+	// IfMatch, when set, is sent as If-Match so the update fails with
+	// ErrScheduleModified if the schedule changed since it was read
+	IfMatch string `json:"-"`
 }
 
 // OneTimeSchedule represents a one-time schedule
 type OneTimeSchedule struct {
-	ID        string   `json:"id"`
-	AgentID   string   `json:"agent_id"`
-	Message   string   `json:"message"`
-	Role      string   `json:"role"`
-	ExecuteAt string   `json:"execute_at"`
-	CreatedAt FlexTime `json:"created_at"`
+	ID          string   `json:"id"`
+	AgentID     string   `json:"agent_id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Message     string   `json:"message"`
+	Role        string   `json:"role"`
+	ExecuteAt   string   `json:"execute_at"`
+	Tags        []string `json:"tags,omitempty"`
+	CreatedAt   FlexTime `json:"created_at"`
+
+	// Owner and Team are only returned by servers that scope schedules to
+	// users or teams
+	Owner string `json:"owner,omitempty"`
+	Team  string `json:"team,omitempty"`
+
+	// ETag is the version the server reported when the schedule was fetched
+	// on its own; empty when the server doesn't send one
+	ETag string `json:"-"`
 }
 
 // OneTimeScheduleCreate represents the payload to create a one-time schedule
 type OneTimeScheduleCreate struct {
-	AgentID   string `json:"agent_id"`
-	Message   string `json:"message"`
-	Role      string `json:"role"`
-	ExecuteAt string `json:"execute_at"`
+	AgentID     string   `json:"agent_id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Message     string   `json:"message"`
+	Role        string   `json:"role"`
+	ExecuteAt   string   `json:"execute_at"`
+	Tags        []string `json:"tags,omitempty"`
 }
 
+// OneTimeScheduleUpdate represents the payload to change a one-time
+// schedule; empty fields are left as they are
+type OneTimeScheduleUpdate struct {
+	AgentID string `json:"agent_id,omitempty"`
+
+	// IfMatch, when set, is sent as If-Match; see RecurringScheduleUpdate
+	IfMatch string `json:"-"`
+}
+
+// Execution result statuses reported by the API
+const (
+	ResultStatusSuccess = "success"
+	ResultStatusFailed  = "failed"
+)
+
 // ExecutionResult represents the result of a schedule execution
 type ExecutionResult struct {
 	ScheduleID   string `json:"schedule_id"`
 	ScheduleType string `json:"schedule_type"`
+	Status       string `json:"status,omitempty"`
 	RunID        string `json:"run_id"`
 	AgentID      string `json:"agent_id"`
 	Message      string `json:"message"`
-	ExecutedAt   string `json:"executed_at"`
+	// Role is only returned by servers that record it with each execution
+	Role       string `json:"role,omitempty"`
+	ExecutedAt string `json:"executed_at"`
+	// Error describes why a failed execution failed
+	Error string `json:"error,omitempty"`
 }
@@ -2,24 +2,123 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 const (
 	ConfigDirName  = ".letta-switchboard"
 	ConfigFileName = "config"
+	EnvPrefix      = "LETTA_SWITCHBOARD"
+
+	// DefaultAgentIDPattern accepts Letta's "agent-<id>" form without
+	// insisting on a UUID, so unusual but valid IDs still pass
+	DefaultAgentIDPattern = `^agent-[A-Za-z0-9_-]+$`
 )
 
+// ConfigFormats are the config file formats, in the order their files are
+// looked for; the file extension selects the format
+var ConfigFormats = []string{"yaml", "yml", "json", "toml"}
+
+// defaults are the values used for keys missing from the config file
+var defaults = map[string]interface{}{
+	"base_url":          "https://letta--switchboard-api.modal.run",
+	"cache":             false,
+	"cache_ttl":         "30s",
+	"max_retries":       3,
+	"max_response_size": "8MB",
+	"min_cron_interval": "5m",
+	"agent_id_pattern":  DefaultAgentIDPattern,
+
+	"recurring_default_role": "user",
+	"onetime_default_role":   "user",
+}
+
 // Config holds the CLI configuration
 type Config struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+	// Version is the layout version of the config file, see ConfigVersion
+	Version int `mapstructure:"version"`
+
+	APIKey string `mapstructure:"api_key"`
+	// APIKeyFile is a file holding the API key, e.g. a mounted secret; it
+	// takes precedence over api_key but not over --api-key or the env var
+	APIKeyFile string        `mapstructure:"api_key_file"`
+	BaseURL    string        `mapstructure:"base_url"`
+	BaseURLs   []string      `mapstructure:"base_urls"`
+	Cache      bool          `mapstructure:"cache"`
+	CacheTTL   time.Duration `mapstructure:"cache_ttl"`
+
+	MaxRetries int    `mapstructure:"max_retries"`
+	UserAgent  string `mapstructure:"user_agent"`
+
+	// Timeout limits each HTTP request; zero keeps the client default
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxResponseSize is the largest response body to read, like "8MB";
+	// "0" means no limit
+	MaxResponseSize string `mapstructure:"max_response_size"`
+
+	// MinCronInterval is the shortest gap between runs recurring create
+	// accepts without --force; zero turns the check off
+	MinCronInterval time.Duration `mapstructure:"min_cron_interval"`
+	// ConfirmCron makes recurring create preview the cron and ask before
+	// creating, as if --confirm-cron were always given
+	ConfirmCron bool `mapstructure:"confirm_cron"`
+	// CronWeekdays is how the server numbers days of the week, like
+	// "sunday-7"; empty sends cron expressions as parsed
+	CronWeekdays string `mapstructure:"cron_weekdays"`
+
+	// Insecure skips TLS certificate verification, for self-signed
+	// certificates in local development
+	Insecure bool `mapstructure:"insecure"`
+
+	// Proxy is the URL of a proxy to send requests through, overriding
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY; empty uses those variables
+	Proxy string `mapstructure:"proxy"`
+
+	// RetryMaxElapsed caps the total time a request may spend retrying; zero
+	// means no cap beyond max_retries
+	RetryMaxElapsed time.Duration `mapstructure:"retry_max_elapsed"`
+	// RetryJitter randomizes retry waits by up to this fraction
+	RetryJitter float64 `mapstructure:"retry_jitter"`
+	// RetryOn lists the HTTP statuses to retry; empty keeps the client's
+	// defaults
+	RetryOn []int `mapstructure:"retry_on"`
+
+	// DefaultAgentID is used by the create commands when --agent-id is omitted
+	DefaultAgentID string `mapstructure:"default_agent_id"`
+
+	// AgentIDPattern is a regular expression agent IDs must match; empty disables the check
+	AgentIDPattern string `mapstructure:"agent_id_pattern"`
+
+	// OutputFormat is the --output used by list and get commands when the
+	// flag isn't given and the command supports it; empty keeps their defaults
+	OutputFormat string `mapstructure:"output_format"`
+
+	// DisplayTimezone is "local" or an IANA zone name that displayed times
+	// are converted to; empty shows times as the API returns them
+	DisplayTimezone string `mapstructure:"display_timezone"`
+
+	// RecurringDefaultRole and OneTimeDefaultRole are the message roles used
+	// when create is run without --role
+	RecurringDefaultRole string `mapstructure:"recurring_default_role"`
+	OneTimeDefaultRole   string `mapstructure:"onetime_default_role"`
+
+	// RoleAliases maps role names users type, e.g. "human", to the role sent
+	// to the API, e.g. "user"
+	RoleAliases map[string]string `mapstructure:"role_aliases"`
+
+	store *Store
 }
 
-// GetConfigDir returns the config directory path
+// GetConfigDir returns the default config directory path, ~/.letta-switchboard
 func GetConfigDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -28,75 +127,355 @@ func GetConfigDir() (string, error) {
 	return filepath.Join(home, ConfigDirName), nil
 }
 
-// InitConfig initializes the configuration
-func InitConfig() error {
-	configDir, err := GetConfigDir()
-	if err != nil {
-		return err
-	}
+// Store holds the configuration sources for one CLI invocation: defaults,
+// the config file, environment variables, and bound flags. Each Store has its
+// own viper instance, so several can be used side by side, and it is safe for
+// concurrent use.
+type Store struct {
+	mu    sync.Mutex
+	v     *viper.Viper
+	dir   string
+	path  string
+	flags map[string]*pflag.Flag
+}
 
+// NewStore reads the configuration from dir, creating the directory and
+// migrating an older config file if needed
+func NewStore(dir string) (*Store, error) {
 	// Create config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	viper.SetConfigName(ConfigFileName)
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(configDir)
+	path, err := findConfigFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
 
 	// Set defaults
-	viper.SetDefault("base_url", "https://letta--switchboard-api.modal.run")
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+
+	// Environment overrides, e.g. LETTA_SWITCHBOARD_BASE_URL
+	v.SetEnvPrefix(EnvPrefix)
+	v.BindEnv("base_url")
+	v.BindEnv("api_key")
+	v.BindEnv("api_key_file")
+	v.BindEnv("default_agent_id")
+	v.BindEnv("proxy")
+
+	// A single-URL environment override replaces any configured failover list
+	if os.Getenv(EnvPrefix+"_BASE_URL") != "" {
+		v.Set("base_urls", []string{})
+	}
+
+	s := &Store{v: v, dir: dir, path: path, flags: map[string]*pflag.Flag{}}
+
+	// Upgrade files written by older versions before reading them
+	if err := migrateConfigFile(path); err != nil {
+		return nil, err
+	}
 
 	// Read config file if it exists
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return fmt.Errorf("failed to read config: %w", err)
+	if err := v.ReadInConfig(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	return s, nil
+}
+
+// findConfigFile returns the config file in dir, whichever of config.yaml,
+// config.yml, config.json or config.toml exists. Having more than one is an
+// error, since only one would be read. With none, config.yaml is used.
+func findConfigFile(dir string) (string, error) {
+	var found []string
+	for _, format := range ConfigFormats {
+		path := filepath.Join(dir, ConfigFileName+"."+format)
+		if _, err := os.Stat(path); err == nil {
+			found = append(found, path)
 		}
 	}
 
-	return nil
+	switch len(found) {
+	case 0:
+		return filepath.Join(dir, ConfigFileName+".yaml"), nil
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("found several config files (%s); remove all but one", strings.Join(found, ", "))
+	}
+}
+
+// Dir returns the config directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Path returns the config file path, which Init may change
+func (s *Store) Path() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.path
+}
+
+// Init writes a new config file in format (see ConfigFormats) holding the
+// default settings. It fails if a config file already exists.
+func (s *Store) Init(format string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	format = strings.ToLower(format)
+	if !isConfigFormat(format) {
+		return "", fmt.Errorf("invalid config format %q: use one of %s", format, strings.Join(ConfigFormats, ", "))
+	}
+	if _, err := os.Stat(s.path); err == nil {
+		return "", fmt.Errorf("config file %s already exists", s.path)
+	}
+
+	path := filepath.Join(s.dir, ConfigFileName+"."+format)
+	file := viper.New()
+	for key, value := range defaults {
+		file.Set(key, value)
+	}
+	file.Set("version", ConfigVersion)
+	if err := file.WriteConfigAs(path); err != nil {
+		return "", fmt.Errorf("failed to write config: %w", err)
+	}
+
+	s.path = path
+	s.v.SetConfigFile(path)
+	return path, nil
 }
 
-// Load loads the current configuration
-func Load() (*Config, error) {
-	var cfg Config
-	if err := viper.Unmarshal(&cfg); err != nil {
+func isConfigFormat(format string) bool {
+	for _, f := range ConfigFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Load resolves the current configuration. The returned Config saves
+// changes back through this Store.
+func (s *Store) Load() (*Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg := Config{store: s}
+	if err := s.v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+
+	if cfg.APIKeyFile != "" && !s.overridden("api_key") {
+		apiKey, err := readAPIKeyFile(cfg.APIKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.APIKey = apiKey
+	}
+
 	return &cfg, nil
 }
 
-// SetAPIKey sets the API key in the config
-func SetAPIKey(apiKey string) error {
-	viper.Set("api_key", apiKey)
-	return saveConfig()
+// BindFlag lets a command-line flag override a config key for this invocation
+func (s *Store) BindFlag(key string, flag *pflag.Flag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flags[key] = flag
+	return s.v.BindPFlag(key, flag)
+}
+
+// OverrideBaseURLs replaces the configured base URLs for this invocation.
+// The first URL is the primary; the rest are tried in order on failover.
+func (s *Store) OverrideBaseURLs(urls []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.v.Set("base_url", urls[0])
+	s.v.Set("base_urls", urls)
 }
 
-// SetBaseURL sets the base URL in the config
-func SetBaseURL(baseURL string) error {
-	viper.Set("base_url", baseURL)
-	return saveConfig()
+// overridden reports whether key was set by a flag or environment variable
+// for this invocation rather than coming from the config file. The caller
+// must hold s.mu.
+func (s *Store) overridden(key string) bool {
+	if flag, ok := s.flags[key]; ok && flag.Changed {
+		return true
+	}
+	_, ok := os.LookupEnv(EnvPrefix + "_" + strings.ToUpper(key))
+	return ok
 }
 
-// saveConfig saves the current configuration to disk
-func saveConfig() error {
-	configDir, err := GetConfigDir()
+// readAPIKeyFile reads an API key from path, ignoring surrounding whitespace
+func readAPIKeyFile(path string) (string, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	data, err := os.ReadFile(path)
 	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	apiKey := strings.TrimSpace(string(data))
+	if apiKey == "" {
+		return "", fmt.Errorf("API key file %s is empty", path)
+	}
+	return apiKey, nil
+}
+
+// Dir returns the config directory
+func (c *Config) Dir() string {
+	return c.store.Dir()
+}
+
+// Path returns the config file path
+func (c *Config) Path() string {
+	return c.store.Path()
+}
+
+// SetAPIKey saves the API key to the config file
+func (c *Config) SetAPIKey(apiKey string) error {
+	if err := c.store.save(map[string]interface{}{"api_key": apiKey}); err != nil {
 		return err
 	}
+	c.APIKey = apiKey
+	return nil
+}
 
-	configPath := filepath.Join(configDir, ConfigFileName+".yaml")
-	if err := viper.WriteConfigAs(configPath); err != nil {
+// SetDefaultAgentID saves the agent ID used when --agent-id is omitted to
+// the config file
+func (c *Config) SetDefaultAgentID(agentID string) error {
+	if err := c.store.save(map[string]interface{}{"default_agent_id": agentID}); err != nil {
+		return err
+	}
+	c.DefaultAgentID = agentID
+	return nil
+}
+
+// SetBaseURLs saves the primary base URL followed by failover URLs to the
+// config file
+func (c *Config) SetBaseURLs(urls []string) error {
+	values := map[string]interface{}{"base_url": urls[0]}
+	if len(urls) > 1 {
+		values["base_urls"] = urls
+	} else {
+		values["base_urls"] = []string{}
+	}
+	if err := c.store.save(values); err != nil {
+		return err
+	}
+	c.BaseURL = urls[0]
+	c.BaseURLs = nil
+	if len(urls) > 1 {
+		c.BaseURLs = urls
+	}
+	return nil
+}
+
+// save writes keys to the config file on disk. Only values already in the
+// file are kept, so flag and environment overrides for the current
+// invocation are never persisted.
+func (s *Store) save(values map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	configPath := s.path
+
+	fileConfig := viper.New()
+	fileConfig.SetConfigFile(configPath)
+	if err := fileConfig.ReadInConfig(); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	for key, value := range values {
+		fileConfig.Set(key, value)
+	}
+	if !fileConfig.IsSet("version") {
+		fileConfig.Set("version", ConfigVersion)
+	}
+	if err := fileConfig.WriteConfigAs(configPath); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
+	for key, value := range values {
+		s.v.Set(key, value)
+	}
+	return nil
+}
+
+// Endpoints returns the base URLs to try in order, primary first
+func (c *Config) Endpoints() []string {
+	if len(c.BaseURLs) > 0 {
+		return c.BaseURLs
+	}
+	return []string{c.BaseURL}
+}
+
+// ProxyURL parses the proxy setting, returning nil when none is set
+func (c *Config) ProxyURL() (*url.URL, error) {
+	if c.Proxy == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(c.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", c.Proxy, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: expected http://, https:// or socks5://", c.Proxy)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", c.Proxy)
+	}
+	return u, nil
+}
+
+// byteUnits are the size suffixes ResponseSizeLimit accepts, in powers of 1024
+var byteUnits = map[string]int64{"": 1, "B": 1, "KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30}
+
+// ResponseSizeLimit parses max_response_size, a number of bytes with an
+// optional KB, MB or GB suffix, returning 0 for no limit
+func (c *Config) ResponseSizeLimit() (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(c.MaxResponseSize))
+	if value == "" {
+		return 0, nil
+	}
+	digits := strings.TrimRightFunc(value, func(r rune) bool { return r < '0' || r > '9' })
+	unit, ok := byteUnits[strings.TrimSpace(value[len(digits):])]
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if !ok || err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid max_response_size %q: expected a size like 8MB, 512KB or 0 for no limit", c.MaxResponseSize)
+	}
+	return n * unit, nil
+}
+
+// ValidateRetryOn checks that retry_on only lists HTTP error statuses, the
+// only ones a request fails with
+func (c *Config) ValidateRetryOn() error {
+	for _, code := range c.RetryOn {
+		if code < 400 || code > 599 {
+			return fmt.Errorf("invalid retry_on status %d: expected an HTTP error status between 400 and 599", code)
+		}
+	}
 	return nil
 }
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	if c.APIKey == "" {
-		return fmt.Errorf("API key not set. Run 'letta-switchboard config set-api-key <key>'")
+		return fmt.Errorf("API key not set. Run 'letta-switchboard config set-api-key <key>' or pass --api-key")
 	}
 	if c.BaseURL == "" {
 		return fmt.Errorf("base URL not set. Run 'letta-switchboard config set-url <url>'")
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestStoreConcurrentInitAndPath(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if _, err := s.Init("json"); err != nil {
+			t.Errorf("Init returned error: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			s.Path()
+		}
+	}()
+	wg.Wait()
+
+	if want := filepath.Join(dir, ConfigFileName+".json"); s.Path() != want {
+		t.Errorf("Path() = %q, want %q", s.Path(), want)
+	}
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadEnvFile reads LETTA_SWITCHBOARD_* variables from a .env file into the
+// process environment. Variables already set in the real environment win, so
+// the precedence is flag > environment > env file > config file. Other
+// variables in the file are ignored.
+//
+// Lines are KEY=VALUE, optionally prefixed with "export"; blank lines and
+// lines starting with # are skipped, and values may be single or double
+// quoted.
+func LoadEnvFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read env file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNum)
+		}
+		key = strings.TrimSpace(key)
+		if !strings.HasPrefix(key, EnvPrefix+"_") {
+			continue
+		}
+		if _, set := os.LookupEnv(key); set {
+			continue
+		}
+
+		if err := os.Setenv(key, unquoteEnvValue(strings.TrimSpace(value))); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read env file: %w", err)
+	}
+	return nil
+}
+
+// unquoteEnvValue strips matching surrounding quotes from a .env value
+func unquoteEnvValue(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// ConfigVersion is the layout version of config files written by this CLI.
+// Bump it and append to migrations whenever the file shape changes.
+const ConfigVersion = 1
+
+// migrations upgrade a config file one version at a time: migrations[i]
+// takes a version i file to version i+1. Each works only from the keys its
+// version had and never reads defaults, which change over time; settings a
+// file leaves out keep following the defaults of whichever CLI reads it.
+var migrations = []func(file *viper.Viper){
+	migrateV0,
+}
+
+// migrateV0 upgrades files from before versioning. Those could hold a
+// base_urls list that only repeats base_url; it is dropped so base_url alone
+// names the server.
+func migrateV0(file *viper.Viper) {
+	urls := file.GetStringSlice("base_urls")
+	if len(urls) == 1 && urls[0] == file.GetString("base_url") {
+		file.Set("base_urls", []string{})
+	}
+}
+
+// migrateConfigFile rewrites the config file at path in the current layout
+// if it was written by an older version, reporting the upgrade on stderr.
+// A missing file needs no migration.
+func migrateConfigFile(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	file := viper.New()
+	file.SetConfigFile(path)
+	if err := file.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	version := file.GetInt("version")
+	if version > ConfigVersion {
+		fmt.Fprintf(os.Stderr, "Warning: %s is config version %d, newer than this CLI supports (%d); some settings may be ignored\n", path, version, ConfigVersion)
+		return nil
+	}
+	if version == ConfigVersion {
+		return nil
+	}
+
+	for v := version; v < ConfigVersion; v++ {
+		migrations[v](file)
+	}
+	file.Set("version", ConfigVersion)
+
+	if err := file.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("failed to write migrated config: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Migrated config file %s from version %d to %d\n", path, version, ConfigVersion)
+	return nil
+}
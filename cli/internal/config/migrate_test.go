@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// writeConfig writes a config file in a temporary directory
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readConfig reads a config file back the way migrateConfigFile does
+func readConfig(t *testing.T, path string) *viper.Viper {
+	t.Helper()
+	file := viper.New()
+	file.SetConfigFile(path)
+	if err := file.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestMigrateV0(t *testing.T) {
+	path := writeConfig(t, `api_key: sk-test
+base_url: https://switchboard.example
+base_urls:
+  - https://switchboard.example
+cache_ttl: 1m
+`)
+
+	if err := migrateConfigFile(path); err != nil {
+		t.Fatalf("migrateConfigFile returned error: %v", err)
+	}
+
+	file := readConfig(t, path)
+	if got := file.GetInt("version"); got != ConfigVersion {
+		t.Errorf("version = %d, want %d", got, ConfigVersion)
+	}
+	if got := file.GetString("api_key"); got != "sk-test" {
+		t.Errorf("api_key = %q, want it kept", got)
+	}
+	if got := file.GetString("base_url"); got != "https://switchboard.example" {
+		t.Errorf("base_url = %q, want it kept", got)
+	}
+	if got := file.GetStringSlice("base_urls"); len(got) != 0 {
+		t.Errorf("base_urls = %v, want the lone repeat of base_url dropped", got)
+	}
+	if got := file.GetString("cache_ttl"); got != "1m" {
+		t.Errorf("cache_ttl = %q, want the file's own value kept", got)
+	}
+
+	// Settings the file left out must keep following the defaults
+	for _, key := range []string{"cache", "max_retries", "max_response_size", "min_cron_interval", "agent_id_pattern", "recurring_default_role", "onetime_default_role"} {
+		if file.IsSet(key) {
+			t.Errorf("%s = %v was written to the file, want it left unset", key, file.Get(key))
+		}
+	}
+}
+
+func TestMigrateV0KeepsFailoverURLs(t *testing.T) {
+	path := writeConfig(t, `base_url: https://a.example
+base_urls:
+  - https://a.example
+  - https://b.example
+`)
+
+	if err := migrateConfigFile(path); err != nil {
+		t.Fatalf("migrateConfigFile returned error: %v", err)
+	}
+
+	got := readConfig(t, path).GetStringSlice("base_urls")
+	if len(got) != 2 || got[0] != "https://a.example" || got[1] != "https://b.example" {
+		t.Errorf("base_urls = %v, want both URLs kept", got)
+	}
+}
+
+func TestMigrateLeavesCurrentAndNewerFilesAlone(t *testing.T) {
+	for _, content := range []string{
+		"version: 1\napi_key: sk-test\n",
+		"version: 99\napi_key: sk-test\nsome_future_key: true\n",
+	} {
+		path := writeConfig(t, content)
+		if err := migrateConfigFile(path); err != nil {
+			t.Fatalf("migrateConfigFile returned error: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("migrateConfigFile rewrote %q as %q", content, data)
+		}
+	}
+}
+
+func TestMigrateMissingFile(t *testing.T) {
+	if err := migrateConfigFile(filepath.Join(t.TempDir(), "config.yaml")); err != nil {
+		t.Errorf("migrateConfigFile on a missing file returned error: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CronDialect selects which cron syntax is accepted
+type CronDialect string
+
+const (
+	// CronDialectStandard is classic five-field cron
+	CronDialectStandard CronDialect = "standard"
+	// CronDialectQuartz also allows Quartz's ? L W and # tokens in the day
+	// fields, for servers that evaluate them
+	CronDialectQuartz CronDialect = "quartz"
+)
+
+// ParseCronDialect resolves a dialect name, with "" meaning standard
+func ParseCronDialect(name string) (CronDialect, error) {
+	switch dialect := CronDialect(strings.ToLower(strings.TrimSpace(name))); dialect {
+	case "", CronDialectStandard:
+		return CronDialectStandard, nil
+	case CronDialectQuartz:
+		return CronDialectQuartz, nil
+	default:
+		return "", fmt.Errorf("invalid cron dialect %q: use standard or quartz", name)
+	}
+}
+
+// quartzFieldPatterns are the characters each field may use in the Quartz
+// dialect: ? L and W in day of month, ? L and # in day of week
+var quartzFieldPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^[\d\*\-,/]+$`),
+	regexp.MustCompile(`^[\d\*\-,/]+$`),
+	regexp.MustCompile(`^(\?|[\d\*\-,/LW]+)$`),
+	regexp.MustCompile(`^[\d\*\-,/]+$`),
+	regexp.MustCompile(`^(\?|[\d\*\-,/L#]+)$`),
+}
+
+// quartzItemPattern matches list items only the Quartz dialect understands:
+// ?, L, LW, L-3, 15W, 5L and 5#2
+var quartzItemPattern = regexp.MustCompile(`^(\?|L|LW|L-\d+|\d+[LW]|\d+#\d+)$`)
+
+// nthWeekdayPattern matches "first monday of the month", "last fri of every
+// month at 5pm" and the like
+var nthWeekdayPattern = regexp.MustCompile(`^(?:every\s+|on\s+)?(?:the\s+)?(first|1st|second|2nd|third|3rd|fourth|4th|last)\s+([a-z]+)\s+of\s+(?:the\s+|each\s+|every\s+)?month(?:\s+at\s+(.+))?$`)
+
+// nthWeekdaySuffixes maps ordinals to the Quartz day-of-week suffix
+var nthWeekdaySuffixes = map[string]string{
+	"first": "#1", "1st": "#1",
+	"second": "#2", "2nd": "#2",
+	"third": "#3", "3rd": "#3",
+	"fourth": "#4", "4th": "#4",
+	"last": "L",
+}
+
+// ParseCronAs is ParseCron for the given dialect. Quartz expressions are
+// passed through uppercased, and phrases like "first monday of the month"
+// become Quartz # or L expressions.
+func ParseCronAs(input string, dialect CronDialect) (string, error) {
+	parse := func(input string) (string, error) {
+		return parseCronAs(input, dialect)
+	}
+	expr, err := parse(input)
+	if err != nil {
+		return "", withSuggestion(err, input, cronVocabulary, parse)
+	}
+	return expr, nil
+}
+
+func parseCronAs(input string, dialect CronDialect) (string, error) {
+	if expr, ok := quartzExpression(input); ok {
+		if dialect != CronDialectQuartz {
+			return "", fmt.Errorf("cron %q uses Quartz syntax (? L W #), which the standard dialect does not allow", expr)
+		}
+		return expr, nil
+	}
+
+	if lower := strings.ToLower(normalizeInput(input)); nthWeekdayPattern.MatchString(lower) {
+		return parseNthWeekday(lower, dialect)
+	}
+
+	return parseCron(input)
+}
+
+// parseNthWeekday handles "first monday of the month at 9am". Standard cron
+// can't express this, so it needs the Quartz dialect.
+func parseNthWeekday(input string, dialect CronDialect) (string, error) {
+	matches := nthWeekdayPattern.FindStringSubmatch(input)
+
+	day, ok := weekdayAbbreviations[matches[2]]
+	if !ok {
+		return "", fmt.Errorf("unknown day: %q (use names like mon, tue, wednesday)", matches[2])
+	}
+	if dialect != CronDialectQuartz {
+		return "", fmt.Errorf("%q needs the Quartz cron dialect: standard cron can't express a particular weekday of the month", input)
+	}
+
+	// Default to 9am if no time specified
+	hour, minute := 9, 0
+	if matches[3] != "" {
+		var err error
+		hour, minute, err = parseTimeOfDay(matches[3])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return cronAt(hour, minute, "?", strconv.Itoa(day)+nthWeekdaySuffixes[matches[1]])
+}
+
+// quartzExpression returns input uppercased if it is a five-field cron
+// expression using at least one Quartz-only token
+func quartzExpression(input string) (string, bool) {
+	fields := strings.Fields(strings.ToUpper(normalizeInput(input)))
+	if len(fields) != 5 {
+		return "", false
+	}
+
+	quartz := false
+	for i, field := range fields {
+		if !quartzFieldPatterns[i].MatchString(field) {
+			return "", false
+		}
+		quartz = quartz || usesQuartzSyntax(field)
+	}
+	if !quartz {
+		return "", false
+	}
+	return strings.Join(fields, " "), true
+}
+
+// usesQuartzSyntax reports whether a cron field contains a Quartz-only item
+func usesQuartzSyntax(field string) bool {
+	for _, item := range strings.Split(strings.ToUpper(field), ",") {
+		if quartzItemPattern.MatchString(item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,270 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearch bounds how far ahead NextFireTimes looks, so expressions
+// that can never fire, like "0 0 31 2 *", fail instead of looping
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+var (
+	monthNames   = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	weekdayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+	lastWeekdayPattern = regexp.MustCompile(`^(\d)l$`)
+	nthWeekdayItem     = regexp.MustCompile(`^(\d)#([1-5])$`)
+)
+
+// cronField is the set of values one cron field allows
+type cronField struct {
+	allowed []bool
+	// any is set for * and ?, which matter for how the day fields combine
+	any bool
+}
+
+// cronSpec is a parsed five-field cron expression
+type cronSpec struct {
+	minute, hour, dayOfMonth, month, dayOfWeek cronField
+
+	// Quartz day tokens: L in day of month, 5L and 5#2 in day of week
+	lastDayOfMonth bool
+	lastWeekdays   []int
+	nthWeekdays    [][2]int
+}
+
+// NextFireTimes returns the next n times after from at which expr fires,
+// evaluated in from's location. Besides standard syntax it understands month
+// and weekday names and the Quartz ? L and # tokens; W is not supported.
+func NextFireTimes(expr string, from time.Time, n int) ([]time.Time, error) {
+	spec, err := parseCronSpec(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var times []time.Time
+	t := from.Truncate(time.Minute).Add(time.Minute)
+	limit := from.Add(maxCronSearch)
+	for len(times) < n {
+		if t.After(limit) {
+			if len(times) == 0 {
+				return nil, fmt.Errorf("cron %q never fires", expr)
+			}
+			break
+		}
+
+		// Skip whole months, days and hours that can't match
+		switch {
+		case !spec.month.allowed[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !spec.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !spec.hour.allowed[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !spec.minute.allowed[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			times = append(times, t)
+			t = t.Add(time.Minute)
+		}
+	}
+	return times, nil
+}
+
+// CheckCron goes further than ValidateRawCron: every value must be in range
+// for its field and the expression must fire at some point, so "99 * * * *"
+// and "0 0 31 2 *" fail. Expressions using W, which the local evaluator
+// doesn't support, only get the shape check.
+func CheckCron(expr string, dialect CronDialect) error {
+	if err := ValidateRawCron(expr, dialect); err != nil {
+		return err
+	}
+	if fields := strings.Fields(expr); strings.Contains(strings.ToUpper(fields[2]), "W") {
+		return nil
+	}
+	_, err := NextFireTimes(expr, time.Now().UTC(), 1)
+	return err
+}
+
+// intervalSamples is how many upcoming fire times MinInterval compares
+const intervalSamples = 100
+
+// MinInterval returns the shortest gap between consecutive fire times of
+// expr over its next runs after from, e.g. one minute for "* * * * *". An
+// expression that fires only once in the search window has no gap and
+// returns 0.
+func MinInterval(expr string, from time.Time) (time.Duration, error) {
+	times, err := NextFireTimes(expr, from, intervalSamples)
+	if err != nil {
+		return 0, err
+	}
+
+	var shortest time.Duration
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); shortest == 0 || gap < shortest {
+			shortest = gap
+		}
+	}
+	return shortest, nil
+}
+
+// matchesDay applies cron's day rule: when both day fields are restricted a
+// day matching either one fires, otherwise the restricted one decides
+func (s *cronSpec) matchesDay(t time.Time) bool {
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	weekday := int(t.Weekday())
+
+	domMatch := s.dayOfMonth.allowed[t.Day()] || (s.lastDayOfMonth && t.Day() == lastDay)
+
+	dowMatch := s.dayOfWeek.allowed[weekday]
+	for _, d := range s.lastWeekdays {
+		dowMatch = dowMatch || (d == weekday && t.Day()+7 > lastDay)
+	}
+	for _, nth := range s.nthWeekdays {
+		dowMatch = dowMatch || (nth[0] == weekday && (t.Day()-1)/7+1 == nth[1])
+	}
+
+	switch {
+	case s.dayOfMonth.any && s.dayOfWeek.any:
+		return true
+	case s.dayOfMonth.any:
+		return dowMatch
+	case s.dayOfWeek.any:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+func parseCronSpec(expr string) (*cronSpec, error) {
+	fields := strings.Fields(strings.ToLower(expr))
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 cron fields, got %d: %q", len(fields), expr)
+	}
+
+	spec := &cronSpec{}
+	var err error
+	if spec.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid %s field %q: %w", cronFieldNames[0], fields[0], err)
+	}
+	if spec.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid %s field %q: %w", cronFieldNames[1], fields[1], err)
+	}
+	if spec.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid %s field %q: %w", cronFieldNames[3], fields[3], err)
+	}
+
+	// Pull the Quartz tokens out of the day fields before parsing the rest
+	var dayOfMonth []string
+	for _, item := range strings.Split(fields[2], ",") {
+		if item == "l" {
+			spec.lastDayOfMonth = true
+			continue
+		}
+		dayOfMonth = append(dayOfMonth, item)
+	}
+	if spec.dayOfMonth, err = parseCronField(strings.Join(dayOfMonth, ","), 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid %s field %q: %w", cronFieldNames[2], fields[2], err)
+	}
+
+	var dayOfWeek []string
+	for _, item := range strings.Split(fields[4], ",") {
+		if m := lastWeekdayPattern.FindStringSubmatch(item); m != nil {
+			d, _ := strconv.Atoi(m[1])
+			spec.lastWeekdays = append(spec.lastWeekdays, d%7)
+			continue
+		}
+		if m := nthWeekdayItem.FindStringSubmatch(item); m != nil {
+			d, _ := strconv.Atoi(m[1])
+			k, _ := strconv.Atoi(m[2])
+			spec.nthWeekdays = append(spec.nthWeekdays, [2]int{d % 7, k})
+			continue
+		}
+		dayOfWeek = append(dayOfWeek, item)
+	}
+	if spec.dayOfWeek, err = parseCronField(strings.Join(dayOfWeek, ","), 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid %s field %q: %w", cronFieldNames[4], fields[4], err)
+	}
+	// 7 is another name for Sunday
+	if spec.dayOfWeek.allowed[7] {
+		spec.dayOfWeek.allowed[0] = true
+	}
+
+	return spec, nil
+}
+
+// parseCronField parses a comma-separated list of *, values, ranges and
+// steps between min and max. An empty field, left when every item was a
+// Quartz token, allows nothing.
+func parseCronField(field string, min, max int, names map[string]int) (cronField, error) {
+	f := cronField{allowed: make([]bool, max+1)}
+	if field == "" {
+		return f, nil
+	}
+	if field == "*" || field == "?" {
+		f.any = true
+	}
+
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i != -1 {
+			var err error
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return f, fmt.Errorf("invalid step in %q", item)
+			}
+			rangePart = item[:i]
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = cronValue(bounds[0], min, max, names); err != nil {
+				return f, err
+			}
+			if hi, err = cronValue(bounds[1], min, max, names); err != nil {
+				return f, err
+			}
+			if lo > hi {
+				return f, fmt.Errorf("range %q runs backwards", rangePart)
+			}
+		default:
+			var err error
+			if lo, err = cronValue(rangePart, min, max, names); err != nil {
+				return f, err
+			}
+			// "5/15" means from 5 to the end in steps of 15
+			hi = lo
+			if step > 1 || strings.Contains(item, "/") {
+				hi = max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			f.allowed[v] = true
+		}
+	}
+	return f, nil
+}
+
+// cronValue parses a number or name within min and max
+func cronValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[s]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported value %q", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", v, min, max)
+	}
+	return v, nil
+}
@@ -3,20 +3,30 @@ package parser
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 // ParseCron converts natural language to cron expression
 func ParseCron(input string) (string, error) {
-	input = strings.TrimSpace(strings.ToLower(input))
+	return ParseCronAs(input, CronDialectStandard)
+}
+
+func parseCron(input string) (string, error) {
+	input = replaceNumberWords(strings.ToLower(normalizeInput(input)))
 	
 	// If it already looks like a cron expression, return as-is
 	if isCronExpression(input) {
 		return input, nil
 	}
 	
-	// "every X minutes"
+	// "at minute 30", "every hour at minute 30": a fixed minute, not a step
+	if minuteOfHourPattern.MatchString(input) {
+		return parseMinuteOfHour(input)
+	}
+	
+	// "every X minutes", "every 15th minute"
 	if strings.HasPrefix(input, "every ") && strings.Contains(input, "minute") {
 		return parseEveryMinutes(input)
 	}
@@ -26,8 +36,13 @@ func ParseCron(input string) (string, error) {
 		return "0 * * * *", nil
 	}
 	
-	// "every day" or "daily"
-	if input == "every day" || input == "daily" {
+	// "every 1 hour", "every 6 hours"
+	if everyHoursPattern.MatchString(input) {
+		return parseEveryHours(input)
+	}
+	
+	// "every day", "every 1 day" or "daily"
+	if input == "every day" || input == "daily" || everyOneDayPattern.MatchString(input) {
 		return "0 9 * * *", nil // 9am daily
 	}
 	
@@ -36,6 +51,11 @@ func ParseCron(input string) (string, error) {
 		return parseDailyAt(input)
 	}
 	
+	// "every tuesday through thursday at 10am"
+	if weekdayRangePattern.MatchString(input) {
+		return parseWeekdayRange(input)
+	}
+	
 	// "every monday/tuesday/etc"
 	if strings.HasPrefix(input, "every ") && containsWeekday(input) {
 		return parseEveryWeekday(input)
@@ -51,6 +71,16 @@ func ParseCron(input string) (string, error) {
 		return "0 9 * * 0,6", nil // 9am Sat-Sun
 	}
 	
+	// "on the 15th", "monthly on the 15th at 10am"
+	if dayOfMonthPattern.MatchString(input) {
+		return parseDayOfMonth(input)
+	}
+	
+	// "weekly on mon,wed,fri at 9am"
+	if strings.HasPrefix(input, "weekly on ") {
+		return parseWeeklyOn(input)
+	}
+	
 	// "monthly"
 	if input == "monthly" {
 		return "0 9 1 * *", nil // 9am on 1st of month
@@ -61,26 +91,175 @@ func ParseCron(input string) (string, error) {
 		return "0 9 * * 1", nil // 9am every Monday
 	}
 	
-	return "", fmt.Errorf("unable to parse cron: %s\n\nSupported formats:\n  - Cron: */5 * * * * (every 5 min)\n  - Minutes: every 5 minutes, every 30 minutes\n  - Hourly: every hour, hourly\n  - Daily: daily, daily at 9am, daily at 14:30\n  - Weekday: every monday, every friday at 3pm\n  - Weekdays: every weekday, weekdays (Mon-Fri at 9am)\n  - Weekly: weekly (every Monday at 9am)\n  - Monthly: monthly (1st of month at 9am)", input)
+	// "twice a day", "3 times a day", "three times daily"
+	if timesPerDayPattern.MatchString(input) {
+		return parseTimesPerDay(input)
+	}
+	
+	return "", fmt.Errorf("unable to parse cron: %s\n\nSupported formats:\n  - Cron: */5 * * * * (every 5 min)\n  - Minutes: every minute, every 5 minutes, every five minutes, every 15th minute\n  - Minute of hour: at minute 30, every hour at minute 30\n  - Hourly: every hour, hourly, every 1 hour, every six hours\n  - Daily: daily, daily at 9am, daily at 14:30\n  - Times per day: twice a day, three times a day, 6 times a day\n  - Weekday: every monday, every friday at 3pm\n  - Weekday range: every tuesday through thursday at 10am, every fri to mon\n  - Weekdays: every weekday, weekdays (Mon-Fri at 9am)\n  - Weekly: weekly (every Monday at 9am), weekly on mon,wed,fri at 9am\n  - Monthly: monthly (1st of month at 9am), on the 15th at 10am, monthly on the 1st\n  - Weekday of month (quartz dialect): first monday of the month, last friday of the month at 5pm", input)
 }
 
 func parseEveryMinutes(input string) (string, error) {
-	// "every 5 minutes", "every 30 minutes"
-	re := regexp.MustCompile(`^every\s+(\d+)\s+minutes?$`)
+	// "every 5 minutes", "every 30 minutes", "every 15th minute", "every minute"
+	re := regexp.MustCompile(`^every\s+(?:(\d+)(?:st|nd|rd|th)?\s+)?minutes?$`)
 	matches := re.FindStringSubmatch(input)
 	
 	if len(matches) != 2 {
 		return "", fmt.Errorf("invalid format: %s (expected: every X minutes)", input)
 	}
 	
+	// "every minute" and "every 1 minute" need no step
+	if matches[1] == "" {
+		return "* * * * *", nil
+	}
 	minutes, _ := strconv.Atoi(matches[1])
 	if minutes <= 0 || minutes > 59 {
 		return "", fmt.Errorf("minutes must be between 1 and 59")
 	}
+	if minutes == 1 {
+		return "* * * * *", nil
+	}
 	
 	return fmt.Sprintf("*/%d * * * *", minutes), nil
 }
 
+var (
+	everyHoursPattern  = regexp.MustCompile(`^every\s+(\d+)\s+hours?$`)
+	everyOneDayPattern = regexp.MustCompile(`^every\s+1\s+days?$`)
+)
+
+func parseEveryHours(input string) (string, error) {
+	// "every 1 hour" -> 0 * * * *, "every 6 hours" -> 0 */6 * * *
+	matches := everyHoursPattern.FindStringSubmatch(input)
+	
+	hours, _ := strconv.Atoi(matches[1])
+	if hours <= 0 || hours > 23 {
+		return "", fmt.Errorf("hours must be between 1 and 23")
+	}
+	if hours == 1 {
+		return "0 * * * *", nil
+	}
+	
+	return fmt.Sprintf("0 */%d * * *", hours), nil
+}
+
+var minuteOfHourPattern = regexp.MustCompile(`^(?:every hour\s+|hourly\s+)?at minute\s+(\d+)(?:\s+(?:of|past)\s+(?:every|each|the)\s+hour)?$`)
+
+func parseMinuteOfHour(input string) (string, error) {
+	// "at minute 30 of every hour" -> 30 * * * *, once an hour at :30
+	matches := minuteOfHourPattern.FindStringSubmatch(input)
+	
+	minute, _ := strconv.Atoi(matches[1])
+	if minute > 59 {
+		return "", fmt.Errorf("minute must be between 0 and 59")
+	}
+	
+	return fmt.Sprintf("%d * * * *", minute), nil
+}
+
+var timesPerDayPattern = regexp.MustCompile(`^(?:(once|twice|thrice)|(\w+)\s+times?)\s+(?:a day|per day|daily)$`)
+
+// timesPerDayWords maps the counts accepted in place of "N times a day";
+// spelled-out numbers like "three" are already digits by then
+var timesPerDayWords = map[string]int{
+	"once":   1,
+	"twice":  2,
+	"thrice": 3,
+}
+
+func parseTimesPerDay(input string) (string, error) {
+	// "twice a day" -> 0 0,12 * * *, "three times a day" -> 0 0,8,16 * * *
+	matches := timesPerDayPattern.FindStringSubmatch(input)
+	
+	count := matches[1]
+	if count == "" {
+		count = matches[2]
+	}
+	
+	n, ok := timesPerDayWords[count]
+	if !ok {
+		var err error
+		if n, err = strconv.Atoi(count); err != nil {
+			return "", fmt.Errorf("invalid format: %s (expected: N times a day)", input)
+		}
+	}
+	
+	if n < 1 || n > 24 {
+		return "", fmt.Errorf("times per day must be between 1 and 24")
+	}
+	
+	// Runs start at midnight UTC and are spread evenly across the day:
+	// run i fires at hour floor(i*24/n), e.g. 5 times a day -> 0,4,9,14,19
+	hours := make([]string, n)
+	for i := 0; i < n; i++ {
+		hours[i] = strconv.Itoa(i * 24 / n)
+	}
+	
+	return fmt.Sprintf("0 %s * * *", strings.Join(hours, ",")), nil
+}
+
+var dayOfMonthPattern = regexp.MustCompile(`^(?:monthly\s+)?on\s+the\s+(\d{1,2})(st|nd|rd|th)?(?:\s+of\s+(?:the|every)\s+month)?(?:\s+at\s+(.+))?$`)
+
+func parseDayOfMonth(input string) (string, error) {
+	// "on the 15th", "monthly on the 1st at 10am", "on the 3rd of every month at 14:30"
+	matches := dayOfMonthPattern.FindStringSubmatch(input)
+	
+	day, _ := strconv.Atoi(matches[1])
+	if day < 1 || day > 31 {
+		return "", fmt.Errorf("day of month must be between 1 and 31")
+	}
+	if suffix := matches[2]; suffix != "" && suffix != ordinalSuffix(day) {
+		return "", fmt.Errorf("invalid ordinal: %d%s (did you mean %d%s?)", day, suffix, day, ordinalSuffix(day))
+	}
+	
+	// Default to 9am if no time specified
+	hour := 9
+	minute := 0
+	
+	if timeStr := matches[3]; timeStr != "" {
+		var err error
+		hour, minute, err = parseTimeOfDay(timeStr)
+		if err != nil {
+			return "", err
+		}
+	}
+	
+	return cronAt(hour, minute, strconv.Itoa(day), "*")
+}
+
+// ordinalSuffix returns the English ordinal suffix for n (1st, 2nd, 11th, 23rd)
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// cronAt builds a cron expression firing at hour:minute on the given
+// day-of-month and day-of-week fields. Every "at TIME" parser goes through
+// it so the minute-first field order lives in one place, and out-of-range
+// values (e.g. "13pm", or hour and minute swapped) are rejected instead of
+// producing a different schedule.
+func cronAt(hour, minute int, dayOfMonth, dayOfWeek string) (string, error) {
+	if hour < 0 || hour > 23 {
+		return "", fmt.Errorf("hour must be between 0 and 23, got %d", hour)
+	}
+	if minute < 0 || minute > 59 {
+		return "", fmt.Errorf("minute must be between 0 and 59, got %d", minute)
+	}
+	
+	return fmt.Sprintf("%d %d %s * %s", minute, hour, dayOfMonth, dayOfWeek), nil
+}
+
 func parseDailyAt(input string) (string, error) {
 	// "daily at 9am", "daily at 14:30"
 	timeStr := strings.TrimPrefix(input, "daily at ")
@@ -91,7 +270,7 @@ func parseDailyAt(input string) (string, error) {
 		return "", err
 	}
 	
-	return fmt.Sprintf("%d %d * * *", minute, hour), nil
+	return cronAt(hour, minute, "*", "*")
 }
 
 func parseEveryWeekday(input string) (string, error) {
@@ -124,7 +303,214 @@ func parseEveryWeekday(input string) (string, error) {
 		}
 	}
 	
-	return fmt.Sprintf("%d %d * * %d", minute, hour, weekdayNum), nil
+	return cronAt(hour, minute, "*", strconv.Itoa(weekdayNum))
+}
+
+// StepMinutes returns the minutes of each hour a cron expression fires at
+// when its minute field is a step like */N, or nil otherwise. Steps restart
+// every hour, so */40 fires at :00 and :40 rather than every 40 minutes.
+func StepMinutes(expr string) []int {
+	parts := strings.Fields(expr)
+	if len(parts) != 5 || !strings.HasPrefix(parts[0], "*/") {
+		return nil
+	}
+	
+	step, err := strconv.Atoi(strings.TrimPrefix(parts[0], "*/"))
+	if err != nil || step <= 0 {
+		return nil
+	}
+	
+	var minutes []int
+	for m := 0; m < 60; m += step {
+		minutes = append(minutes, m)
+	}
+	return minutes
+}
+
+// JitterCron delays the minute field of expr by a random number of minutes,
+// at most maxMinutes, using pick(n) to choose a value in [0, n). It handles a
+// fixed minute or list like "0" and "0,30", and steps like "*/15". The offset
+// never pushes a run into the next hour or past the next step, so "50 9 * * *"
+// moves by at most 9 minutes. It returns the new expression and the offset.
+func JitterCron(expr string, maxMinutes int, pick func(n int) int) (string, int, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return "", 0, fmt.Errorf("expected 5 cron fields, got %d: %q", len(parts), expr)
+	}
+	
+	minuteField := parts[0]
+	if i := strings.Index(minuteField, "/"); i != -1 {
+		start := 0
+		if from := minuteField[:i]; from != "*" {
+			v, err := strconv.Atoi(from)
+			if err != nil || v < 0 || v > 59 {
+				return "", 0, fmt.Errorf("jitter needs a fixed minute or a */N step, got %q", minuteField)
+			}
+			start = v
+		}
+		step, err := strconv.Atoi(minuteField[i+1:])
+		if err != nil || step <= 0 {
+			return "", 0, fmt.Errorf("invalid step in %q", minuteField)
+		}
+		
+		offset := pick(min(maxMinutes, step-1-start%step)+1)
+		if offset == 0 {
+			return expr, 0, nil
+		}
+		parts[0] = fmt.Sprintf("%d-59/%d", start+offset, step)
+		return strings.Join(parts, " "), offset, nil
+	}
+	
+	var minutes []int
+	latest := 0
+	for _, item := range strings.Split(minuteField, ",") {
+		v, err := strconv.Atoi(item)
+		if err != nil || v < 0 || v > 59 {
+			return "", 0, fmt.Errorf("jitter needs a fixed minute or a */N step, got %q", minuteField)
+		}
+		minutes = append(minutes, v)
+		latest = max(latest, v)
+	}
+	
+	offset := pick(min(maxMinutes, 59-latest)+1)
+	items := make([]string, len(minutes))
+	for i, m := range minutes {
+		items[i] = strconv.Itoa(m + offset)
+	}
+	parts[0] = strings.Join(items, ",")
+	return strings.Join(parts, " "), offset, nil
+}
+
+// weekdayAbbreviations maps full and abbreviated day names to cron weekday numbers
+var weekdayAbbreviations = map[string]int{
+	"sunday": 0, "sun": 0,
+	"monday": 1, "mon": 1,
+	"tuesday": 2, "tue": 2, "tues": 2,
+	"wednesday": 3, "wed": 3,
+	"thursday": 4, "thu": 4, "thur": 4, "thurs": 4,
+	"friday": 5, "fri": 5,
+	"saturday": 6, "sat": 6,
+}
+
+// weekdayRangePattern matches "every tuesday through thursday at 10am",
+// "every mon to fri" and "every mon-wed"
+var weekdayRangePattern = regexp.MustCompile(`^every\s+([a-z]+)\s*(?:\s(?:through|thru|to)\s|-)\s*([a-z]+)(?:\s+at\s+(.+))?$`)
+
+func parseWeekdayRange(input string) (string, error) {
+	// "every tuesday through thursday" -> 0 9 * * 2-4
+	matches := weekdayRangePattern.FindStringSubmatch(input)
+	
+	start, ok := weekdayAbbreviations[matches[1]]
+	if !ok {
+		return "", fmt.Errorf("unknown day: %q (use names like mon, tue, wednesday)", matches[1])
+	}
+	end, ok := weekdayAbbreviations[matches[2]]
+	if !ok {
+		return "", fmt.Errorf("unknown day: %q (use names like mon, tue, wednesday)", matches[2])
+	}
+	
+	// A range past Saturday wraps around the week: fri through mon is
+	// Sunday-Monday plus Friday-Saturday
+	var days string
+	switch {
+	case start == end:
+		return "", fmt.Errorf("weekday range %q starts and ends on the same day", matches[1]+" through "+matches[2])
+	case start < end:
+		days = fmt.Sprintf("%d-%d", start, end)
+	default:
+		days = weekdaySpan(0, end) + "," + weekdaySpan(start, 6)
+	}
+	
+	// Default to 9am if no time specified
+	hour, minute := 9, 0
+	if matches[3] != "" {
+		var err error
+		hour, minute, err = parseTimeOfDay(matches[3])
+		if err != nil {
+			return "", err
+		}
+	}
+	
+	return cronAt(hour, minute, "*", days)
+}
+
+// weekdaySpan formats the days from start to end as a cron list item
+func weekdaySpan(start, end int) string {
+	if start == end {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+func parseWeeklyOn(input string) (string, error) {
+	// "weekly on mon,wed,fri", "weekly on monday, thursday at 14:30"
+	re := regexp.MustCompile(`^weekly\s+on\s+(.+?)(?:\s+at\s+(.+))?$`)
+	matches := re.FindStringSubmatch(input)
+	
+	if len(matches) != 3 {
+		return "", fmt.Errorf("invalid format: %s (expected: weekly on mon,wed,fri at 9am)", input)
+	}
+	
+	seen := map[int]bool{}
+	var days []int
+	for _, name := range strings.Split(matches[1], ",") {
+		name = strings.TrimSpace(name)
+		day, ok := weekdayAbbreviations[name]
+		if !ok {
+			return "", fmt.Errorf("unknown day: %q (use names like mon, tue, wednesday)", name)
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Ints(days)
+	
+	// Default to 9am if no time specified
+	hour := 9
+	minute := 0
+	
+	if timeStr := matches[2]; timeStr != "" {
+		var err error
+		hour, minute, err = parseTimeOfDay(timeStr)
+		if err != nil {
+			return "", err
+		}
+	}
+	
+	dayList := make([]string, len(days))
+	for i, day := range days {
+		dayList[i] = strconv.Itoa(day)
+	}
+	
+	return cronAt(hour, minute, "*", strings.Join(dayList, ","))
+}
+
+// cronFieldNames names the five cron fields in order, for error messages
+var cronFieldNames = []string{"minute", "hour", "day of month", "month", "day of week"}
+
+var rawCronFieldPattern = regexp.MustCompile(`^[A-Za-z0-9\*\-,/\?#]+$`)
+
+// ValidateRawCron checks that expr has the shape of a five-field cron
+// expression without interpreting it, so names like MON-FRI or JAN pass
+// through for the server to evaluate. Quartz-only tokens are rejected unless
+// dialect is CronDialectQuartz.
+func ValidateRawCron(expr string, dialect CronDialect) error {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return fmt.Errorf("expected 5 cron fields (minute hour day-of-month month day-of-week), got %d: %q", len(fields), expr)
+	}
+	
+	for i, field := range fields {
+		if !rawCronFieldPattern.MatchString(field) {
+			return fmt.Errorf("invalid %s field %q in cron: %q", cronFieldNames[i], field, expr)
+		}
+		if dialect != CronDialectQuartz && usesQuartzSyntax(field) {
+			return fmt.Errorf("%s field %q in cron %q uses Quartz syntax (? L W #), which the standard dialect does not allow", cronFieldNames[i], field, expr)
+		}
+	}
+	
+	return nil
 }
 
 func isCronExpression(input string) bool {
@@ -0,0 +1,208 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		// cron expressions pass through
+		{"*/5 * * * *", "*/5 * * * *"},
+		{"30 14 * * 1-5", "30 14 * * 1-5"},
+
+		// minutes
+		{"every minute", "* * * * *"},
+		{"every 5 minutes", "*/5 * * * *"},
+		{"every 15th minute", "*/15 * * * *"},
+
+		// minute of hour
+		{"at minute 30", "30 * * * *"},
+		{"every hour at minute 5", "5 * * * *"},
+		{"at minute 45 past every hour", "45 * * * *"},
+
+		// hourly
+		{"every hour", "0 * * * *"},
+		{"hourly", "0 * * * *"},
+		{"every 6 hours", "0 */6 * * *"},
+
+		// daily: minute first, then hour
+		{"daily", "0 9 * * *"},
+		{"every day", "0 9 * * *"},
+		{"daily at 9am", "0 9 * * *"},
+		{"daily at 14:30", "30 14 * * *"},
+		{"daily at 9:05", "5 9 * * *"},
+		{"daily at 0:59", "59 0 * * *"},
+		{"daily at 12am", "0 0 * * *"},
+		{"daily at noon", "0 12 * * *"},
+
+		// times per day
+		{"twice a day", "0 0,12 * * *"},
+		{"5 times a day", "0 0,4,9,14,19 * * *"},
+
+		// single weekday
+		{"every monday", "0 9 * * 1"},
+		{"every friday at 3pm", "0 15 * * 5"},
+		{"every sunday at 23:45", "45 23 * * 0"},
+
+		// weekday ranges
+		{"every tuesday through thursday at 10am", "0 10 * * 2-4"},
+		{"every mon-wed", "0 9 * * 1-3"},
+		{"every fri to mon at 8:15", "15 8 * * 0-1,5-6"},
+		{"every sat to sun", "0 9 * * 0,6"},
+
+		// weekdays and weekends
+		{"every weekday", "0 9 * * 1-5"},
+		{"weekends", "0 9 * * 0,6"},
+
+		// day of month
+		{"on the 15th", "0 9 15 * *"},
+		{"monthly on the 1st at 10am", "0 10 1 * *"},
+		{"on the 3rd of every month at 14:30", "30 14 3 * *"},
+
+		// weekly
+		{"weekly", "0 9 * * 1"},
+		{"weekly on fri,mon,wed", "0 9 * * 1,3,5"},
+		{"weekly on monday, thursday at 14:30", "30 14 * * 1,4"},
+
+		// monthly
+		{"monthly", "0 9 1 * *"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCron(tt.input)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCron(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseCronRejectsOutOfRange(t *testing.T) {
+	tests := []string{
+		"daily at 25:00",
+		"daily at 24:00",
+		"daily at 9:75",
+		"daily at 9:60",
+		"daily at 13pm",
+		"daily at 0am",
+		"every friday at 30:15",
+		"every mon-fri at 10:99",
+		"on the 15th at 24:30",
+		"weekly on mon at 9:61",
+		"at minute 60",
+		"every 60 minutes",
+		"every 24 hours",
+		"on the 32nd",
+		"on the 2th",
+		"25 times a day",
+	}
+
+	for _, input := range tests {
+		if got, err := ParseCron(input); err == nil {
+			t.Errorf("ParseCron(%q) = %q, want an error", input, got)
+		}
+	}
+}
+
+func TestCronAt(t *testing.T) {
+	tests := []struct {
+		hour, minute int
+		want         string
+		wantErr      bool
+	}{
+		{hour: 9, minute: 0, want: "0 9 * * *"},
+		{hour: 14, minute: 30, want: "30 14 * * *"},
+		{hour: 0, minute: 59, want: "59 0 * * *"},
+		{hour: 23, minute: 0, want: "0 23 * * *"},
+		{hour: 24, minute: 0, wantErr: true},
+		{hour: -1, minute: 0, wantErr: true},
+		{hour: 9, minute: 60, wantErr: true},
+		{hour: 9, minute: -1, wantErr: true},
+		// hour and minute swapped
+		{hour: 30, minute: 14, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := cronAt(tt.hour, tt.minute, "*", "*")
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("cronAt(%d, %d) = %q, want an error", tt.hour, tt.minute, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("cronAt(%d, %d) returned error: %v", tt.hour, tt.minute, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("cronAt(%d, %d) = %q, want %q", tt.hour, tt.minute, got, tt.want)
+		}
+	}
+}
+
+func TestParseSingularUnits(t *testing.T) {
+	from := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	cronTests := []struct {
+		input    string
+		want     string
+		interval time.Duration
+	}{
+		{"every 1 minute", "* * * * *", time.Minute},
+		{"every 1 minutes", "* * * * *", time.Minute},
+		{"every one minute", "* * * * *", time.Minute},
+		{"every 1st minute", "* * * * *", time.Minute},
+		{"every 1 hour", "0 * * * *", time.Hour},
+		{"every one hour", "0 * * * *", time.Hour},
+		{"every 1 day", "0 9 * * *", 24 * time.Hour},
+		{"every 1 days", "0 9 * * *", 24 * time.Hour},
+	}
+	for _, tt := range cronTests {
+		got, err := ParseCron(tt.input)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCron(%q) = %q, want %q", tt.input, got, tt.want)
+			continue
+		}
+		if err := CheckCron(got, CronDialectStandard); err != nil {
+			t.Errorf("CheckCron(%q) for %q returned error: %v", got, tt.input, err)
+		}
+		if interval, err := MinInterval(got, from); err != nil || interval != tt.interval {
+			t.Errorf("MinInterval(%q) for %q = %s, %v; want %s", got, tt.input, interval, err, tt.interval)
+		}
+	}
+
+	timeTests := []struct {
+		input string
+		want  string
+	}{
+		{"in 1 minute", "2025-01-15T10:01:00Z"},
+		{"in 1 min", "2025-01-15T10:01:00Z"},
+		{"in 1 hour", "2025-01-15T11:00:00Z"},
+		{"in 1 hr", "2025-01-15T11:00:00Z"},
+		{"in 1 day", "2025-01-16T10:00:00Z"},
+		{"in one day", "2025-01-16T10:00:00Z"},
+		{"1 hour ago", "2025-01-15T09:00:00Z"},
+		{"1 day ago", "2025-01-14T10:00:00Z"},
+	}
+	for _, tt := range timeTests {
+		got, err := ParseTimeAt(tt.input, from)
+		if err != nil {
+			t.Errorf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WeekdayNumbering is how a server numbers the days in the day-of-week
+// cron field
+type WeekdayNumbering string
+
+const (
+	// WeekdaysAsParsed sends the field as written, where 0 and 7 both mean
+	// Sunday
+	WeekdaysAsParsed WeekdayNumbering = ""
+	// WeekdaysSunday0 is 0-6 from Sunday, as in classic cron
+	WeekdaysSunday0 WeekdayNumbering = "sunday-0"
+	// WeekdaysSunday7 is 1-7 from Monday, with Sunday as 7 (ISO 8601)
+	WeekdaysSunday7 WeekdayNumbering = "sunday-7"
+	// WeekdaysSunday1 is 1-7 from Sunday, as in Quartz
+	WeekdaysSunday1 WeekdayNumbering = "sunday-1"
+)
+
+// ParseWeekdayNumbering resolves a numbering name, with "" meaning the
+// expression is sent as parsed
+func ParseWeekdayNumbering(name string) (WeekdayNumbering, error) {
+	switch numbering := WeekdayNumbering(strings.ToLower(strings.TrimSpace(name))); numbering {
+	case WeekdaysAsParsed, WeekdaysSunday0, WeekdaysSunday7, WeekdaysSunday1:
+		return numbering, nil
+	default:
+		return "", fmt.Errorf("invalid weekday numbering %q: use sunday-0, sunday-7 or sunday-1", name)
+	}
+}
+
+// weekdayItemPattern matches numeric day-of-week items: 5, 1-5, 1-5/2, */2
+// and 1/2
+var weekdayItemPattern = regexp.MustCompile(`^(\*|\d+)(?:-(\d+))?(?:/(\d+))?$`)
+
+// weekdaySuffixPattern matches Quartz day-of-week items: 5#2 and 5L
+var weekdaySuffixPattern = regexp.MustCompile(`^(\d+)(#\d+|L)$`)
+
+// NormalizeWeekdays rewrites the day-of-week field of a five-field cron
+// expression, read with Sunday as 0 or 7, in the given numbering. Ranges,
+// steps and lists are expanded to the days they cover and written back as
+// sorted runs, so "*/2" and "0-6" keep their meaning; names like MON-FRI, *
+// and ? are left as they are.
+func NormalizeWeekdays(expr string, numbering WeekdayNumbering) (string, error) {
+	if numbering == WeekdaysAsParsed {
+		return expr, nil
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("cron %q must have 5 fields", expr)
+	}
+
+	var days [8]bool
+	var numeric bool
+	var others []string
+	for _, item := range strings.Split(fields[4], ",") {
+		item = strings.ToUpper(item)
+		if m := weekdaySuffixPattern.FindStringSubmatch(item); m != nil {
+			day, err := weekdayValue(m[1])
+			if err != nil {
+				return "", fmt.Errorf("cron %q: %w", expr, err)
+			}
+			others = append(others, strconv.Itoa(renumberWeekday(day, numbering))+m[2])
+			continue
+		}
+
+		ok, err := addWeekdayItem(&days, item, numbering)
+		if err != nil {
+			return "", fmt.Errorf("cron %q: %w", expr, err)
+		}
+		if !ok {
+			others = append(others, item)
+			continue
+		}
+		numeric = true
+	}
+
+	var items []string
+	if numeric {
+		items = append(items, joinWeekdayRuns(days))
+	}
+	fields[4] = strings.Join(append(items, others...), ",")
+	return strings.Join(fields, " "), nil
+}
+
+// addWeekdayItem marks the days a numeric item like 5, 1-5 or */2 covers,
+// renumbered, and reports false for items it leaves alone
+func addWeekdayItem(days *[8]bool, item string, numbering WeekdayNumbering) (bool, error) {
+	m := weekdayItemPattern.FindStringSubmatch(item)
+	if m == nil || item == "*" || (m[1] == "*" && m[2] != "") {
+		return false, nil
+	}
+
+	start, end := 0, 6
+	if m[1] != "*" {
+		var err error
+		if start, err = weekdayValue(m[1]); err != nil {
+			return false, err
+		}
+		// A lone day is itself, unless a step makes it the start of a range
+		end = start
+		if m[3] != "" {
+			end = 6
+		}
+	}
+	if m[2] != "" {
+		var err error
+		if end, err = weekdayValue(m[2]); err != nil {
+			return false, err
+		}
+	}
+	if start > end {
+		return false, fmt.Errorf("invalid day-of-week range %q", item)
+	}
+	step := 1
+	if m[3] != "" {
+		step, _ = strconv.Atoi(m[3])
+		if step < 1 {
+			return false, fmt.Errorf("invalid day-of-week step %q", item)
+		}
+	}
+
+	for day := start; day <= end; day += step {
+		days[renumberWeekday(day%7, numbering)] = true
+	}
+	return true, nil
+}
+
+// weekdayValue parses a day-of-week number, 0-7 with both 0 and 7 meaning
+// Sunday
+func weekdayValue(s string) (int, error) {
+	day, err := strconv.Atoi(s)
+	if err != nil || day > 7 {
+		return 0, fmt.Errorf("invalid day of week %q: expected 0-7", s)
+	}
+	return day, nil
+}
+
+// renumberWeekday converts a day from 0-6 with Sunday as 0 to numbering
+func renumberWeekday(day int, numbering WeekdayNumbering) int {
+	switch numbering {
+	case WeekdaysSunday7:
+		if day%7 == 0 {
+			return 7
+		}
+	case WeekdaysSunday1:
+		return day%7 + 1
+	}
+	return day % 7
+}
+
+// joinWeekdayRuns renders a set of days as a list of ranges, like "1-5,7"
+func joinWeekdayRuns(days [8]bool) string {
+	var parts []string
+	for day := 0; day < len(days); day++ {
+		if !days[day] {
+			continue
+		}
+		end := day
+		for end+1 < len(days) && days[end+1] {
+			end++
+		}
+		if end > day {
+			parts = append(parts, fmt.Sprintf("%d-%d", day, end))
+		} else {
+			parts = append(parts, strconv.Itoa(day))
+		}
+		day = end
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,92 @@
+package parser
+
+import "testing"
+
+func TestNormalizeWeekdays(t *testing.T) {
+	tests := []struct {
+		dow       string
+		numbering WeekdayNumbering
+		want      string
+	}{
+		// sent as parsed
+		{"0-6", WeekdaysAsParsed, "0-6"},
+		{"7", WeekdaysAsParsed, "7"},
+
+		// classic cron: Sunday is 0
+		{"0", WeekdaysSunday0, "0"},
+		{"7", WeekdaysSunday0, "0"},
+		{"1-5", WeekdaysSunday0, "1-5"},
+		{"5-7", WeekdaysSunday0, "0,5-6"},
+		{"0-7", WeekdaysSunday0, "0-6"},
+		{"3,1-2,2", WeekdaysSunday0, "1-3"},
+
+		// ISO 8601: Sunday is 7
+		{"0", WeekdaysSunday7, "7"},
+		{"7", WeekdaysSunday7, "7"},
+		{"0,6", WeekdaysSunday7, "6-7"},
+		{"0-6", WeekdaysSunday7, "1-7"},
+		{"5-7", WeekdaysSunday7, "5-7"},
+
+		// Quartz: 1-7 from Sunday, and ranges wrap past Saturday
+		{"0", WeekdaysSunday1, "1"},
+		{"7", WeekdaysSunday1, "1"},
+		{"1-5", WeekdaysSunday1, "2-6"},
+		{"5-7", WeekdaysSunday1, "1,6-7"},
+		{"0-6", WeekdaysSunday1, "1-7"},
+		{"0,6", WeekdaysSunday1, "1,7"},
+		{"*/2", WeekdaysSunday1, "1,3,5,7"},
+		{"1/2", WeekdaysSunday1, "2,4,6"},
+		{"5#2", WeekdaysSunday1, "6#2"},
+		{"5L", WeekdaysSunday1, "6L"},
+		{"0,3-4", WeekdaysSunday1, "1,4-5"},
+
+		// left alone
+		{"*", WeekdaysSunday1, "*"},
+		{"?", WeekdaysSunday1, "?"},
+		{"MON-FRI", WeekdaysSunday1, "MON-FRI"},
+	}
+
+	for _, tt := range tests {
+		expr := "0 9 * * " + tt.dow
+		got, err := NormalizeWeekdays(expr, tt.numbering)
+		if err != nil {
+			t.Errorf("NormalizeWeekdays(%q, %q) returned error: %v", expr, tt.numbering, err)
+			continue
+		}
+		if want := "0 9 * * " + tt.want; got != want {
+			t.Errorf("NormalizeWeekdays(%q, %q) = %q, want %q", expr, tt.numbering, got, want)
+		}
+	}
+}
+
+func TestNormalizeWeekdaysRejectsInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"0 9 * * 8",
+		"0 9 * * 5-2",
+		"0 9 * * 1-5/0",
+		"0 9 * *",
+	} {
+		if got, err := NormalizeWeekdays(expr, WeekdaysSunday1); err == nil {
+			t.Errorf("NormalizeWeekdays(%q) = %q, want an error", expr, got)
+		}
+	}
+}
+
+func TestParseWeekdayNumbering(t *testing.T) {
+	tests := map[string]WeekdayNumbering{
+		"":          WeekdaysAsParsed,
+		"sunday-0":  WeekdaysSunday0,
+		" Sunday-7": WeekdaysSunday7,
+		"SUNDAY-1":  WeekdaysSunday1,
+	}
+	for name, want := range tests {
+		got, err := ParseWeekdayNumbering(name)
+		if err != nil || got != want {
+			t.Errorf("ParseWeekdayNumbering(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseWeekdayNumbering("monday-1"); err == nil {
+		t.Error("ParseWeekdayNumbering(\"monday-1\") returned no error")
+	}
+}
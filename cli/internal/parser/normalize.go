@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// punctuationReplacer maps typographic quotes and dashes to their ASCII
+// equivalents; word processors turn "mon-fri" into "mon–fri" as you type
+var punctuationReplacer = strings.NewReplacer(
+	"\u2018", "'", // left single quote
+	"\u2019", "'", // right single quote / apostrophe
+	"\u201a", "'", // single low-9 quote
+	"\u201b", "'", // single high-reversed-9 quote
+	"\u2032", "'", // prime
+	"\u201c", `"`, // left double quote
+	"\u201d", `"`, // right double quote
+	"\u201e", `"`, // double low-9 quote
+	"\u2033", `"`, // double prime
+	"\u2010", "-", // hyphen
+	"\u2011", "-", // non-breaking hyphen
+	"\u2012", "-", // figure dash
+	"\u2013", "-", // en dash
+	"\u2014", "-", // em dash
+	"\u2212", "-", // minus sign
+)
+
+// normalizeInput cleans up text pasted from documents before matching:
+// unicode spaces (NBSP, thin space, ...) become regular spaces, zero-width
+// characters are dropped, smart quotes and dashes become ASCII, quotes
+// wrapping the whole input are stripped, and runs of whitespace collapse to
+// one space.
+func normalizeInput(input string) string {
+	input = punctuationReplacer.Replace(input)
+
+	input = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\ufeff':
+			// zero-width space, non-joiner, joiner, and byte order mark
+			return -1
+		case unicode.IsSpace(r):
+			return ' '
+		}
+		return r
+	}, input)
+
+	input = strings.Join(strings.Fields(input), " ")
+
+	for _, q := range []string{`"`, "'"} {
+		if len(input) >= 2 && strings.HasPrefix(input, q) && strings.HasSuffix(input, q) {
+			input = strings.TrimSpace(input[1 : len(input)-1])
+		}
+	}
+
+	return input
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizeInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "every 5 minutes", "every 5 minutes"},
+		{"smart double quotes", "“every weekday”", "every weekday"},
+		{"smart single quotes", "‘daily at 9am’", "daily at 9am"},
+		{"low-9 quotes", "„every monday”", "every monday"},
+		{"curly apostrophe", "o’clock", "o'clock"},
+		{"non-breaking space", "every\u00a05\u00a0minutes", "every 5 minutes"},
+		{"thin space", "in\u20092\u2009hours", "in 2 hours"},
+		{"narrow no-break space", "daily at 9\u202fam", "daily at 9 am"},
+		{"zero-width space", "every\u200b monday", "every monday"},
+		{"byte order mark", "\ufeffevery weekday", "every weekday"},
+		{"en dash", "every mon–fri", "every mon-fri"},
+		{"em dash", "every mon—fri", "every mon-fri"},
+		{"non-breaking hyphen", "every mon\u2011fri", "every mon-fri"},
+		{"runs of whitespace", "  every \t 5\n minutes  ", "every 5 minutes"},
+		{"quotes wrapping padding", "“ every weekday ”", "every weekday"},
+		{"unmatched quote kept", "“every weekday", `"every weekday`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeInput(tt.input); got != tt.want {
+				t.Errorf("normalizeInput(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWordPastedInput(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	cronTests := []struct {
+		input string
+		want  string
+	}{
+		{"“every weekday”", "0 9 * * 1-5"},
+		{"daily\u00a0at\u00a09am", "0 9 * * *"},
+		{"‘every 15 minutes’", "*/15 * * * *"},
+		{"every mon–fri", "0 9 * * 1-5"},
+		{"every tuesday – thursday at 10am", "0 10 * * 2-4"},
+		{"daily at 14:30\u200b", "30 14 * * *"},
+	}
+	for _, tt := range cronTests {
+		got, err := ParseCron(tt.input)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCron(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	timeTests := []struct {
+		input string
+		want  string
+	}{
+		{"“tomorrow at 9am”", "2025-01-16T09:00:00Z"},
+		{"in\u00a05\u2009minutes", "2025-01-15T10:05:00Z"},
+		{"tomorrow at 9am −30m", "2025-01-16T08:30:00Z"},
+		{"2025–01–20 09:00", "2025-01-20T09:00:00Z"},
+	}
+	for _, tt := range timeTests {
+		got, err := ParseTimeAt(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Spelled-out numbers and ordinals, indexed by value. Schedules never need
+// more than 59, so the tens stop at fifty.
+var (
+	unitWords       = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	tensWords       = []string{"", "", "twenty", "thirty", "forty", "fifty"}
+	unitOrdinals    = []string{"", "first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth", "tenth", "eleventh", "twelfth", "thirteenth", "fourteenth", "fifteenth", "sixteenth", "seventeenth", "eighteenth", "nineteenth"}
+	tensOrdinals    = []string{"", "", "twentieth", "thirtieth", "fortieth", "fiftieth"}
+	numberWordLists = [][]string{unitWords, tensWords, unitOrdinals, tensOrdinals}
+)
+
+// numberVocabulary is every number word, for typo suggestions
+func numberVocabulary() []string {
+	var words []string
+	for _, list := range numberWordLists {
+		for _, w := range list {
+			if w != "" {
+				words = append(words, w)
+			}
+		}
+	}
+	return words
+}
+
+// replaceNumberWords rewrites spelled-out numbers in lowercase input as
+// digits, so "every five minutes" reads as "every 5 minutes" and "on the
+// twenty-first" as "on the 21st". Compounds may use a hyphen or a space.
+func replaceNumberWords(input string) string {
+	words := strings.Split(input, " ")
+	var out []string
+	for i := 0; i < len(words); i++ {
+		// "forty five" is read as "forty-five"
+		if i+1 < len(words) && indexOf(tensWords, words[i]) > 0 {
+			if n, ordinal, ok := numberWord(words[i] + "-" + words[i+1]); ok {
+				out = append(out, formatNumber(n, ordinal))
+				i++
+				continue
+			}
+		}
+		if n, ordinal, ok := numberWord(words[i]); ok {
+			out = append(out, formatNumber(n, ordinal))
+			continue
+		}
+		out = append(out, words[i])
+	}
+	return strings.Join(out, " ")
+}
+
+// numberWord parses one number word such as "five", "forty-five" or
+// "twenty-first", reporting whether it is an ordinal
+func numberWord(word string) (n int, ordinal bool, ok bool) {
+	if tens, unit, found := strings.Cut(word, "-"); found {
+		t := indexOf(tensWords, tens)
+		if t <= 0 {
+			return 0, false, false
+		}
+		if u := indexOf(unitWords, unit); u >= 1 && u <= 9 {
+			return t*10 + u, false, true
+		}
+		if u := indexOf(unitOrdinals, unit); u >= 1 && u <= 9 {
+			return t*10 + u, true, true
+		}
+		return 0, false, false
+	}
+
+	if n := indexOf(unitWords, word); n >= 0 {
+		return n, false, true
+	}
+	if n := indexOf(tensWords, word); n > 0 {
+		return n * 10, false, true
+	}
+	if n := indexOf(unitOrdinals, word); n > 0 {
+		return n, true, true
+	}
+	if n := indexOf(tensOrdinals, word); n > 0 {
+		return n * 10, true, true
+	}
+	return 0, false, false
+}
+
+func formatNumber(n int, ordinal bool) string {
+	if ordinal {
+		return strconv.Itoa(n) + ordinalSuffix(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// indexOf returns the position of word in list, or -1
+func indexOf(list []string, word string) int {
+	for i, w := range list {
+		if w == word {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplaceNumberWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"every five minutes", "every 5 minutes"},
+		{"in two hours", "in 2 hours"},
+		{"every forty-five minutes", "every 45 minutes"},
+		{"every forty five minutes", "every 45 minutes"},
+		{"on the twenty-first", "on the 21st"},
+		{"on the first", "on the 1st"},
+		{"on the thirtieth", "on the 30th"},
+		{"every 15 minutes", "every 15 minutes"},
+		{"every someone", "every someone"},
+		// "forty" followed by a word that is not a number
+		{"every forty minutes", "every 40 minutes"},
+	}
+
+	for _, tt := range tests {
+		if got := replaceNumberWords(tt.input); got != tt.want {
+			t.Errorf("replaceNumberWords(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNumberWord(t *testing.T) {
+	tests := []struct {
+		word    string
+		n       int
+		ordinal bool
+		ok      bool
+	}{
+		{"zero", 0, false, true},
+		{"nineteen", 19, false, true},
+		{"fifty", 50, false, true},
+		{"fifty-nine", 59, false, true},
+		{"twenty-third", 23, true, true},
+		{"twelfth", 12, true, true},
+		{"fortieth", 40, true, true},
+		{"twenty-zero", 0, false, false},
+		{"twenty-ten", 0, false, false},
+		{"ten-five", 0, false, false},
+		{"sixty", 0, false, false},
+		{"minutes", 0, false, false},
+	}
+
+	for _, tt := range tests {
+		n, ordinal, ok := numberWord(tt.word)
+		if n != tt.n || ordinal != tt.ordinal || ok != tt.ok {
+			t.Errorf("numberWord(%q) = %d, %v, %v, want %d, %v, %v", tt.word, n, ordinal, ok, tt.n, tt.ordinal, tt.ok)
+		}
+	}
+}
+
+func TestParseNumberWords(t *testing.T) {
+	cronTests := []struct {
+		input string
+		want  string
+	}{
+		{"every five minutes", "*/5 * * * *"},
+		{"every Fifteen minutes", "*/15 * * * *"},
+		{"every six hours", "0 */6 * * *"},
+		{"three times a day", "0 0,8,16 * * *"},
+		{"on the twenty-first at 10am", "0 10 21 * *"},
+		{"every one minute", "* * * * *"},
+	}
+	for _, tt := range cronTests {
+		got, err := ParseCron(tt.input)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCron(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	timeTests := []struct {
+		input string
+		want  string
+	}{
+		{"in two hours", "2025-01-15T12:00:00Z"},
+		{"in three days", "2025-01-18T10:00:00Z"},
+		{"in forty-five minutes", "2025-01-15T10:45:00Z"},
+		{"in Ten Minutes", "2025-01-15T10:10:00Z"},
+		{"two hours ago", "2025-01-15T08:00:00Z"},
+	}
+	for _, tt := range timeTests {
+		got, err := ParseTimeAt(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// cronVocabulary lists the words ParseCron understands
+var cronVocabulary = append([]string{
+	"every", "minute", "minutes", "hour", "hourly", "day", "daily", "at",
+	"weekday", "weekdays", "weekend", "weekends", "weekly", "monthly", "month",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+	"once", "twice", "thrice", "times", "per", "on", "the", "of", "past", "each",
+	"last", "through", "thru", "to",
+	"noon", "midnight", "morning", "afternoon", "evening", "am", "pm",
+}, numberVocabulary()...)
+
+// timeVocabulary lists the words ParseTime understands
+var timeVocabulary = append([]string{
+	"in", "minute", "minutes", "hour", "hours", "day", "days",
+	"tomorrow", "next", "week", "month", "now", "at", "ago",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+	"noon", "midnight", "morning", "afternoon", "evening", "am", "pm",
+}, numberVocabulary()...)
+
+var wordPattern = regexp.MustCompile(`[a-z]+`)
+
+// withSuggestion adds a "Did you mean" line to err when correcting typos in
+// input against vocabulary yields something parse accepts
+func withSuggestion(err error, input string, vocabulary []string, parse func(string) (string, error)) error {
+	suggestion := suggestCorrection(strings.ToLower(normalizeInput(input)), vocabulary)
+	if suggestion == "" {
+		return err
+	}
+	if _, parseErr := parse(suggestion); parseErr != nil {
+		return err
+	}
+
+	// Put the hint right after the first line, ahead of any format listing
+	msg := err.Error()
+	hint := "\n\nDid you mean \"" + suggestion + "\"?"
+	if i := strings.Index(msg, "\n"); i != -1 {
+		return errors.New(msg[:i] + hint + msg[i:])
+	}
+	return errors.New(msg + hint)
+}
+
+// suggestCorrection replaces each unknown word in input with the closest
+// vocabulary word, returning "" if nothing was close enough to change
+func suggestCorrection(input string, vocabulary []string) string {
+	changed := false
+	corrected := wordPattern.ReplaceAllStringFunc(input, func(word string) string {
+		if match := closestWord(word, vocabulary); match != "" && match != word {
+			changed = true
+			return match
+		}
+		return word
+	})
+
+	if !changed {
+		return ""
+	}
+	return corrected
+}
+
+// closestWord returns the vocabulary word nearest to word, or "" when none is
+// within the allowed distance. Short words are left alone since almost
+// anything is one or two edits away from them.
+func closestWord(word string, vocabulary []string) string {
+	if len(word) < 4 {
+		return ""
+	}
+
+	maxDistance := 1
+	if len(word) > 5 {
+		maxDistance = 2
+	}
+
+	best := ""
+	bestDistance := maxDistance
+	for _, candidate := range vocabulary {
+		if candidate == word {
+			return word
+		}
+		// On a tie prefer the longer word, since typos more often drop a
+		// letter ("minuts", "daly") than add one
+		d := editDistance(word, candidate)
+		if d < bestDistance || (d == bestDistance && len(candidate) > len(best)) {
+			best = candidate
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// editDistance is the Damerau-Levenshtein distance (with adjacent
+// transpositions) between a and b
+func editDistance(a, b string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := 0; j <= len(b); j++ {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d := min3(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && rows[i-2][j-2]+1 < d {
+				d = rows[i-2][j-2] + 1
+			}
+			rows[i][j] = d
+		}
+	}
+
+	return rows[len(a)][len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -8,9 +8,47 @@ import (
 	"time"
 )
 
-// ParseTime converts natural language or ISO 8601 timestamps to ISO 8601 format
+// ParseTime converts natural language or ISO 8601 timestamps to ISO 8601
+// format, reading dates and times of day in UTC
 func ParseTime(input string) (string, error) {
-	input = strings.TrimSpace(strings.ToLower(input))
+	return ParseTimeAt(input, time.Now().UTC())
+}
+
+// ParseTimeIn is ParseTime with dates and times of day read in loc, so
+// "tomorrow at noon" is noon there, DST included. A nil loc means UTC.
+func ParseTimeIn(input string, loc *time.Location) (string, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return ParseTimeAt(input, time.Now().In(loc))
+}
+
+// ParseTimeAt is ParseTime with relative times like "in 5 minutes" or
+// "tomorrow" measured from now instead of the current time, and dates and
+// times of day read in now's location. The result is always in UTC.
+func ParseTimeAt(input string, now time.Time) (string, error) {
+	parse := func(input string) (string, error) {
+		return parseTime(input, now)
+	}
+	parsed, err := parse(input)
+	if err != nil {
+		return "", withSuggestion(err, input, timeVocabulary, parse)
+	}
+	
+	t, err := time.Parse(time.RFC3339, parsed)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+func parseTime(input string, now time.Time) (string, error) {
+	input = normalizeInput(input)
+	
+	// "tomorrow at 9am +30m", "next monday at 3pm -1h": a base time with an offset
+	if matches := offsetSuffixPattern.FindStringSubmatch(input); matches != nil {
+		return parseWithOffset(matches[1], matches[2], now)
+	}
 	
 	// Try parsing as ISO 8601 first
 	formats := []string{
@@ -22,23 +60,44 @@ func ParseTime(input string) (string, error) {
 	}
 	
 	for _, format := range formats {
-		if t, err := time.Parse(format, input); err == nil {
+		// Timestamps without an offset are in now's location
+		if t, err := time.ParseInLocation(format, input, now.Location()); err == nil {
 			return t.UTC().Format(time.RFC3339), nil
 		}
 	}
 	
-	now := time.Now().UTC()
+	// Unix epoch in seconds or milliseconds, e.g. 1730980800
+	if isAllDigits(input) {
+		return parseUnixTimestamp(input)
+	}
+	
+	input = replaceNumberWords(strings.ToLower(input))
 	
 	// "in X minutes/hours/days"
 	if strings.HasPrefix(input, "in ") {
 		return parseRelativeTime(input, now)
 	}
 	
+	// "X minutes/hours/days ago", for backfilling past schedules
+	if strings.HasSuffix(input, " ago") {
+		return parseAgo(input, now)
+	}
+	
+	// ISO 8601 duration from now: "PT30M", "PT2H", "P1D", "P1DT12H"
+	if isoDurationPrefix.MatchString(input) {
+		return parseISODuration(input, now)
+	}
+	
 	// "tomorrow at HH:MM"
 	if strings.HasPrefix(input, "tomorrow") {
 		return parseTomorrow(input, now)
 	}
 	
+	// "next week", "next month at 10am"
+	if strings.HasPrefix(input, "next week") || strings.HasPrefix(input, "next month") {
+		return parseNextPeriod(input, now)
+	}
+	
 	// "next monday/tuesday/etc at HH:MM"
 	if strings.HasPrefix(input, "next ") {
 		return parseNextDay(input, now)
@@ -49,7 +108,39 @@ func ParseTime(input string) (string, error) {
 		return now.Format(time.RFC3339), nil
 	}
 	
-	return "", fmt.Errorf("unable to parse time: %s\n\nSupported formats:\n  - ISO 8601: 2025-11-12T19:30:00Z\n  - Relative: in 5 minutes, in 2 hours, in 3 days\n  - Tomorrow: tomorrow at 9am, tomorrow at 14:30\n  - Next day: next monday at 3pm, next friday at 10:00\n  - Now: now", input)
+	return "", fmt.Errorf("unable to parse time: %s\n\nSupported formats:\n  - ISO 8601: 2025-11-12T19:30:00Z\n  - Unix timestamp: 1730980800 (seconds) or 1730980800000 (milliseconds)\n  - Relative: in 5 minutes, in two hours, in 3 days\n  - Past (with --allow-past): 5 minutes ago, 2 days ago\n  - ISO 8601 duration: PT30M, PT2H, P1D, P1DT12H\n  - Tomorrow: tomorrow at 9am, tomorrow at noon, tomorrow at 14:30\n  - Next day: next monday at 3pm, next friday at 10:00\n  - Next week/month: next week, next month at 10am\n  - Now: now\n  - With an offset: tomorrow at 9am +30m, next monday at 3pm -1h", input)
+}
+
+func isAllDigits(input string) bool {
+	if input == "" {
+		return false
+	}
+	for _, r := range input {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseUnixTimestamp(input string) (string, error) {
+	// 13 digits is milliseconds (1730980800000), up to 11 digits is seconds (1730980800)
+	value, err := strconv.ParseInt(input, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid unix timestamp: %s", input)
+	}
+	
+	var t time.Time
+	switch {
+	case len(input) == 13:
+		t = time.UnixMilli(value)
+	case len(input) <= 11:
+		t = time.Unix(value, 0)
+	default:
+		return "", fmt.Errorf("invalid unix timestamp: %s (expected seconds or 13-digit milliseconds)", input)
+	}
+	
+	return t.UTC().Format(time.RFC3339), nil
 }
 
 func parseRelativeTime(input string, now time.Time) (string, error) {
@@ -79,14 +170,127 @@ func parseRelativeTime(input string, now time.Time) (string, error) {
 	return t.Format(time.RFC3339), nil
 }
 
+var (
+	isoDurationPrefix  = regexp.MustCompile(`^p(?:[\dt]|$)`)
+	isoDurationPattern = regexp.MustCompile(`^p(?:(\d+)y)?(?:(\d+)m)?(?:(\d+)w)?(?:(\d+)d)?(?:t(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?)?$`)
+)
+
+func parseISODuration(input string, now time.Time) (string, error) {
+	// "p1y2m3w4dt5h6m7s", already lowercased; calendar units (years, months,
+	// weeks, days) are applied with AddDate, clock units as a fixed duration
+	matches := isoDurationPattern.FindStringSubmatch(input)
+	if matches == nil || strings.HasSuffix(input, "t") {
+		return "", fmt.Errorf("invalid ISO 8601 duration: %s (expected e.g. PT30M, PT2H, P1D, P1DT12H)", strings.ToUpper(input))
+	}
+	
+	values := make([]int, len(matches)-1)
+	for i, m := range matches[1:] {
+		if m == "" {
+			continue
+		}
+		v, err := strconv.Atoi(m)
+		if err != nil {
+			return "", fmt.Errorf("invalid ISO 8601 duration: %s (value too large)", strings.ToUpper(input))
+		}
+		values[i] = v
+	}
+	
+	years, months, weeks, days := values[0], values[1], values[2], values[3]
+	hours, minutes, seconds := values[4], values[5], values[6]
+	
+	t := now.AddDate(years, months, weeks*7+days).
+		Add(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second)
+	
+	if !t.After(now) {
+		return "", fmt.Errorf("ISO 8601 duration must be greater than zero: %s", strings.ToUpper(input))
+	}
+	
+	return t.Format(time.RFC3339), nil
+}
+
+var (
+	offsetSuffixPattern = regexp.MustCompile(`^(.+?)\s+([+-]\s*\S+)$`)
+	offsetPattern       = regexp.MustCompile(`^([+-])\s*((?:\d+[mhd])+)$`)
+	offsetPartPattern   = regexp.MustCompile(`(\d+)([mhd])`)
+)
+
+// parseWithOffset parses base as any other time, then shifts it by offset, a
+// sign followed by one or more of m (minutes), h (hours) and d (days), e.g.
+// "+30m", "-1h" or "+1d2h"
+func parseWithOffset(base, offset string, now time.Time) (string, error) {
+	matches := offsetPattern.FindStringSubmatch(strings.ToLower(offset))
+	if matches == nil {
+		return "", fmt.Errorf("invalid time offset: %s (expected a sign and amounts in m, h or d, e.g. +30m, -1h, +1d2h)", offset)
+	}
+	
+	parsed, err := parseTime(base, now)
+	if err != nil {
+		return "", err
+	}
+	t, err := time.Parse(time.RFC3339, parsed)
+	if err != nil {
+		return "", err
+	}
+	// Days are counted on the local calendar, across DST changes
+	t = t.In(now.Location())
+	
+	sign := 1
+	if matches[1] == "-" {
+		sign = -1
+	}
+	
+	for _, part := range offsetPartPattern.FindAllStringSubmatch(matches[2], -1) {
+		value, err := strconv.Atoi(part[1])
+		if err != nil {
+			return "", fmt.Errorf("invalid time offset: %s (value too large)", offset)
+		}
+		value *= sign
+		
+		switch part[2] {
+		case "m":
+			t = t.Add(time.Duration(value) * time.Minute)
+		case "h":
+			t = t.Add(time.Duration(value) * time.Hour)
+		case "d":
+			t = t.AddDate(0, 0, value)
+		}
+	}
+	
+	return t.Format(time.RFC3339), nil
+}
+
+func parseAgo(input string, now time.Time) (string, error) {
+	// "5 minutes ago", "2 hours ago", "3 days ago"
+	re := regexp.MustCompile(`^(\d+)\s*(minute|minutes|min|hour|hours|hr|hrs|h|day|days|d)s?\s+ago$`)
+	matches := re.FindStringSubmatch(input)
+	
+	if len(matches) != 3 {
+		return "", fmt.Errorf("invalid relative time format: %s (expected: X minutes/hours/days ago)", input)
+	}
+	
+	value, _ := strconv.Atoi(matches[1])
+	unit := matches[2]
+	
+	var t time.Time
+	switch {
+	case strings.HasPrefix(unit, "min"):
+		t = now.Add(-time.Duration(value) * time.Minute)
+	case strings.HasPrefix(unit, "h"):
+		t = now.Add(-time.Duration(value) * time.Hour)
+	default:
+		t = now.AddDate(0, 0, -value)
+	}
+	
+	return t.Format(time.RFC3339), nil
+}
+
 func parseTomorrow(input string, now time.Time) (string, error) {
 	// "tomorrow" or "tomorrow at 9am" or "tomorrow at 14:30"
 	tomorrow := now.AddDate(0, 0, 1)
 	
 	if input == "tomorrow" {
 		// Default to 9am tomorrow
-		tomorrow = time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, time.UTC)
-		return tomorrow.Format(time.RFC3339), nil
+		return localTime(tomorrow, 9, 0).Format(time.RFC3339), nil
 	}
 	
 	// Parse "tomorrow at HH:MM" or "tomorrow at 9am"
@@ -101,8 +305,7 @@ func parseTomorrow(input string, now time.Time) (string, error) {
 		return "", err
 	}
 	
-	t := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), hour, minute, 0, 0, time.UTC)
-	return t.Format(time.RFC3339), nil
+	return localTime(tomorrow, hour, minute).Format(time.RFC3339), nil
 }
 
 func parseNextDay(input string, now time.Time) (string, error) {
@@ -133,8 +336,66 @@ func parseNextDay(input string, now time.Time) (string, error) {
 		return "", err
 	}
 	
-	t := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), hour, minute, 0, 0, time.UTC)
-	return t.Format(time.RFC3339), nil
+	return localTime(targetDate, hour, minute).Format(time.RFC3339), nil
+}
+
+func parseNextPeriod(input string, now time.Time) (string, error) {
+	// "next week" is 7 days from today, "next month" the same day next month;
+	// both default to 9am unless "at TIME" is given
+	re := regexp.MustCompile(`^next\s+(week|month)(?:\s+at\s+(.+))?$`)
+	matches := re.FindStringSubmatch(input)
+	
+	if len(matches) != 3 {
+		return "", fmt.Errorf("expected format 'next week|month [at TIME]': %s", input)
+	}
+	
+	var target time.Time
+	if matches[1] == "week" {
+		target = now.AddDate(0, 0, 7)
+	} else {
+		target = addMonthClamped(now)
+	}
+	
+	hour := 9
+	minute := 0
+	
+	if timeStr := matches[2]; timeStr != "" {
+		var err error
+		hour, minute, err = parseTimeOfDay(timeStr)
+		if err != nil {
+			return "", err
+		}
+	}
+	
+	return localTime(target, hour, minute).Format(time.RFC3339), nil
+}
+
+// localTime returns hour:minute on day's date in day's location. A time
+// skipped by a DST change (2:30am when clocks jump from 2am to 3am) moves
+// forward by the length of the jump, as cron does, rather than landing
+// before the requested time; a time that happens twice is the first one.
+func localTime(day time.Time, hour, minute int) time.Time {
+	t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+	
+	// Compare wall clocks as if in UTC, which has no gaps
+	want := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, time.UTC)
+	got := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	return t.Add(want.Sub(got))
+}
+
+// addMonthClamped moves to the same day next month, clamping to the last day
+// of the month when it is shorter (Jan 31 -> Feb 28/29) instead of rolling
+// over into the following month like time.AddDate does
+func addMonthClamped(t time.Time) time.Time {
+	firstOfNext := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstOfNext.AddDate(0, 1, -1).Day()
+	
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	
+	return time.Date(firstOfNext.Year(), firstOfNext.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
 }
 
 func parseTimeOfDay(input string) (hour int, minute int, err error) {
@@ -146,6 +407,12 @@ func parseTimeOfDay(input string) (hour int, minute int, err error) {
 		return 12, 0, nil
 	case "midnight", "12am":
 		return 0, 0, nil
+	case "morning":
+		return 9, 0, nil
+	case "afternoon":
+		return 15, 0, nil
+	case "evening":
+		return 18, 0, nil
 	}
 	
 	// Parse "3pm", "9am"
@@ -153,6 +420,9 @@ func parseTimeOfDay(input string) (hour int, minute int, err error) {
 	matches := re.FindStringSubmatch(input)
 	if len(matches) == 3 {
 		h, _ := strconv.Atoi(matches[1])
+		if h < 1 || h > 12 {
+			return 0, 0, fmt.Errorf("invalid time: %s (hour must be 1-12 with am/pm)", input)
+		}
 		if matches[2] == "pm" && h != 12 {
 			h += 12
 		}
@@ -0,0 +1,322 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone data for %s not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseTimeAcrossDST(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+
+	// Clocks jump from 2am to 3am on Sunday 2026-03-08 and fall back from
+	// 2am to 1am on Sunday 2026-11-01
+	beforeSpring := time.Date(2026, 3, 7, 10, 0, 0, 0, ny)
+	beforeFall := time.Date(2026, 10, 31, 10, 0, 0, 0, ny)
+
+	tests := []struct {
+		name  string
+		input string
+		now   time.Time
+		want  string
+	}{
+		{"noon after spring forward is EDT", "tomorrow at noon", beforeSpring, "2026-03-08T16:00:00Z"},
+		{"midnight before spring forward is EST", "tomorrow at midnight", beforeSpring, "2026-03-08T05:00:00Z"},
+		{"default 9am after spring forward", "tomorrow", beforeSpring, "2026-03-08T13:00:00Z"},
+		{"time in the gap moves forward", "tomorrow at 2:30", beforeSpring, "2026-03-08T07:30:00Z"},
+		{"gap via next weekday", "next sunday at 2:30", beforeSpring, "2026-03-08T07:30:00Z"},
+		{"noon after fall back is EST", "tomorrow at noon", beforeFall, "2026-11-01T17:00:00Z"},
+		{"repeated time is the first one", "tomorrow at 1:30", beforeFall, "2026-11-01T05:30:00Z"},
+		{"repeated time via next weekday", "next sunday at 1:30", beforeFall, "2026-11-01T05:30:00Z"},
+		{"in 1 day keeps the wall clock", "in 1 day", beforeSpring, "2026-03-08T14:00:00Z"},
+		{"in 24 hours is elapsed time", "in 24 hours", beforeSpring, "2026-03-08T15:00:00Z"},
+		{"offset in days keeps the wall clock", "tomorrow at 9am +1d", beforeFall.AddDate(0, 0, -1), "2026-11-01T14:00:00Z"},
+		{"offset in hours is elapsed time", "tomorrow at 9am +24h", beforeFall.AddDate(0, 0, -1), "2026-11-01T13:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimeAt(tt.input, tt.now)
+			if err != nil {
+				t.Fatalf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTimeInReadsTimestampsInLocation(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		input string
+		loc   *time.Location
+		want  string
+	}{
+		{"2026-03-07 12:00", ny, "2026-03-07T17:00:00Z"},
+		{"2026-03-08 12:00", ny, "2026-03-08T16:00:00Z"},
+		{"2026-11-01 12:00", ny, "2026-11-01T17:00:00Z"},
+		{"2026-03-08 12:00", nil, "2026-03-08T12:00:00Z"},
+		{"2026-03-08T12:00:00Z", ny, "2026-03-08T12:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeIn(tt.input, tt.loc)
+		if err != nil {
+			t.Errorf("ParseTimeIn(%q, %v) returned error: %v", tt.input, tt.loc, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeIn(%q, %v) = %s, want %s", tt.input, tt.loc, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeAtRelative(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2025, 1, 15, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"now", "2025-01-15T10:20:30Z"},
+		{"in 5 minutes", "2025-01-15T10:25:30Z"},
+		{"in 90 min", "2025-01-15T11:50:30Z"},
+		{"in 2 hours", "2025-01-15T12:20:30Z"},
+		{"in 3h", "2025-01-15T13:20:30Z"},
+		{"in 3 days", "2025-01-18T10:20:30Z"},
+		{"tomorrow", "2025-01-16T09:00:00Z"},
+		{"tomorrow at 14:30", "2025-01-16T14:30:00Z"},
+		{"tomorrow at 3pm", "2025-01-16T15:00:00Z"},
+		{"tomorrow at midnight", "2025-01-16T00:00:00Z"},
+		{"next friday at 10:00", "2025-01-17T10:00:00Z"},
+		{"next monday at 3pm", "2025-01-20T15:00:00Z"},
+		{"next wednesday at 9am", "2025-01-22T09:00:00Z"},
+		{"next week", "2025-01-22T09:00:00Z"},
+		{"next month at 10am", "2025-02-15T10:00:00Z"},
+		{"tomorrow at 9am +30m", "2025-01-16T09:30:00Z"},
+		{"next monday at 3pm -1h", "2025-01-20T14:00:00Z"},
+		{"in 5 minutes +1d2h", "2025-01-16T12:25:30Z"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeAt(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeAtNextMonthClamps(t *testing.T) {
+	now := time.Date(2025, 1, 31, 8, 0, 0, 0, time.UTC)
+
+	got, err := ParseTimeAt("next month", now)
+	if err != nil {
+		t.Fatalf("ParseTimeAt returned error: %v", err)
+	}
+	if want := "2025-02-28T09:00:00Z"; got != want {
+		t.Errorf("ParseTimeAt(%q) = %s, want %s", "next month", got, want)
+	}
+}
+
+func TestParseTimeAtReturnsUTC(t *testing.T) {
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+	now := time.Date(2025, 1, 15, 23, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"now", "2025-01-15T14:00:00Z"},
+		{"in 2 hours", "2025-01-15T16:00:00Z"},
+		{"tomorrow at 9am", "2025-01-16T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeAt(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeAtRejectsBadTimes(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{
+		"",
+		"later",
+		"in five",
+		"tomorrow at 25:00",
+		"tomorrow at 13pm",
+		"next funday at 3pm",
+		"tomorrow at 9am +30x",
+	} {
+		if got, err := ParseTimeAt(input, now); err == nil {
+			t.Errorf("ParseTimeAt(%q) = %s, want an error", input, got)
+		}
+	}
+}
+
+func TestParseTimeUnixTimestamp(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		// seconds, up to 11 digits
+		{"1730980800", "2024-11-07T12:00:00Z"},
+		{"0", "1970-01-01T00:00:00Z"},
+		{"86400", "1970-01-02T00:00:00Z"},
+		{"32503680000", "3000-01-01T00:00:00Z"},
+		// milliseconds, 13 digits; sub-second precision is dropped
+		{"1730980800000", "2024-11-07T12:00:00Z"},
+		{"1730980800999", "2024-11-07T12:00:00Z"},
+		// pasted with whitespace around it
+		{" 1730980800 ", "2024-11-07T12:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeAt(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeUnixTimestampRejectsAmbiguousLengths(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{
+		"173098080000",         // 12 digits: neither seconds nor milliseconds
+		"17309808000000",       // 14 digits
+		"99999999999999999999", // overflows int64
+	} {
+		if got, err := ParseTimeAt(input, now); err == nil {
+			t.Errorf("ParseTimeAt(%q) = %s, want an error", input, got)
+		}
+	}
+}
+
+func TestParseTimeISODuration(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"PT30M", "2025-01-15T10:30:00Z"},
+		{"PT2H", "2025-01-15T12:00:00Z"},
+		{"PT45S", "2025-01-15T10:00:45Z"},
+		{"PT1H30M", "2025-01-15T11:30:00Z"},
+		{"P1D", "2025-01-16T10:00:00Z"},
+		{"P1DT12H", "2025-01-16T22:00:00Z"},
+		{"P2W", "2025-01-29T10:00:00Z"},
+		{"P1Y", "2026-01-15T10:00:00Z"},
+		// M before T is months, after T minutes
+		{"P1M", "2025-02-15T10:00:00Z"},
+		{"PT1M", "2025-01-15T10:01:00Z"},
+		{"p1dt2h", "2025-01-16T12:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeAt(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeISODurationRejectsMalformed(t *testing.T) {
+	now := time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{
+		"P",
+		"PT",
+		"P1DT",
+		"PT0M",
+		"P0D",
+		"PT1.5H",
+		"PT30",
+		"P1H",
+		"PT2H30M1D",
+		"P99999999999999999999D",
+	} {
+		if got, err := ParseTimeAt(input, now); err == nil {
+			t.Errorf("ParseTimeAt(%q) = %s, want an error", input, got)
+		}
+	}
+}
+
+func TestParseTimeAgo(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"5 minutes ago", "2025-01-15T09:55:00Z"},
+		{"1 minute ago", "2025-01-15T09:59:00Z"},
+		{"90 min ago", "2025-01-15T08:30:00Z"},
+		{"2 hours ago", "2025-01-15T08:00:00Z"},
+		{"2h ago", "2025-01-15T08:00:00Z"},
+		{"3 days ago", "2025-01-12T10:00:00Z"},
+		{"1 day ago", "2025-01-14T10:00:00Z"},
+		{"five minutes ago", "2025-01-15T09:55:00Z"},
+		{"20 days ago", "2024-12-26T10:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeAt(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTimeAt(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeAt(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeAgoRejectsMalformed(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{
+		"minutes ago",
+		"a while ago",
+		"5 weeks ago",
+		"-5 minutes ago",
+	} {
+		if got, err := ParseTimeAt(input, now); err == nil {
+			t.Errorf("ParseTimeAt(%q) = %s, want an error", input, got)
+		}
+	}
+}